@@ -0,0 +1,152 @@
+/*
+ * translate_error_definitions.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go error definitions translator
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"log"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type ErrorDefinition struct {
+	Name        string
+	Code        int
+	Description string
+}
+
+var errorPattern = regexp.MustCompile(`^ERROR\(\s*(\w+),\s*(\d+),\s*"(.*)"\s*\)`)
+
+func translateName(old string) string {
+	return strings.Replace(strings.Title(strings.Replace(old, "_", " ", -1)), " ", "", -1)
+}
+
+func main() {
+	var inFile string
+	var outFile string
+	flag.StringVar(&inFile, "in", "stdin", "Input file")
+	flag.StringVar(&outFile, "out", "stdout", "Output file")
+	flag.Parse()
+
+	var err error
+	var data []byte
+
+	if inFile == "stdin" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(inFile)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var defs []ErrorDefinition
+	for _, line := range strings.Split(string(data), "\n") {
+		m := errorPattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		code, err := strconv.Atoi(m[2])
+		if err != nil {
+			log.Fatal(err)
+		}
+		// success and end_of_stream are never returned by the client
+		if code < 1000 {
+			continue
+		}
+		defs = append(defs, ErrorDefinition{m[1], code, m[3]})
+	}
+
+	var buf bytes.Buffer
+
+	fmt.Fprint(&buf, `/*
+ * generated_errors.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// DO NOT EDIT THIS FILE BY HAND. This file was generated using
+// translate_error_definitions.go, part of the FoundationDB repository, and a
+// copy of the error_definitions.h file.
+
+// To regenerate this file, from the top level of a FoundationDB repository
+// checkout, run:
+// $ go run bindings/go/src/_util/translate_error_definitions.go < flow/include/flow/error_definitions.h > bindings/go/src/fdb/generated_errors.go
+
+package fdb
+
+// Errors that may be returned by the FoundationDB client. An Error may be
+// compared with one of these values with == or errors.Is.
+var (
+`)
+
+	for _, def := range defs {
+		fmt.Fprintf(&buf, "\t// Err%s is error %d (%s): %s.\n", translateName(def.Name), def.Code, def.Name, strings.TrimSuffix(def.Description, "."))
+		fmt.Fprintf(&buf, "\tErr%s = Error{Code: %d}\n\n", translateName(def.Name), def.Code)
+	}
+
+	fmt.Fprint(&buf, `)
+
+var errorNames = map[int]string{
+`)
+
+	for _, def := range defs {
+		fmt.Fprintf(&buf, "\t%d: %q,\n", def.Code, def.Name)
+	}
+
+	fmt.Fprint(&buf, "}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if outFile == "stdout" {
+		_, err = os.Stdout.Write(src)
+	} else {
+		err = os.WriteFile(outFile, src, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
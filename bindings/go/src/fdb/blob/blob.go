@@ -0,0 +1,651 @@
+/*
+ * blob.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Blob Layer
+
+// Package blob stores objects of arbitrary size in FoundationDB, dividing them
+// into chunks that are each stored under a separate key.
+//
+// An object larger than a single transaction may write is uploaded across
+// several transactions. Its chunks are written under an upload ID that is not
+// visible to readers until the upload is committed by writing the metadata of
+// the object, which names the upload ID, its size and its SHA-256 checksum.
+// Replacing an object commits the new upload and clears the chunks of the old
+// one in a single transaction, so readers see either the old object or the new
+// one in full.
+//
+// Uploads that are never committed, for example because the writing process
+// died, leave their chunks behind. They are recorded under the store so that
+// CollectGarbage can remove them.
+//
+// All data is stored in the subspace passed to New, laid out as follows:
+//
+//	("meta", name) = (uploadID, size, chunkSize, checksum)
+//	("data", uploadID, offset) = chunk
+//	("upload", uploadID) = (name, start time in Unix nanoseconds)
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/subspace"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/tuple"
+)
+
+const (
+	defaultChunkSize        = 10000
+	defaultTransactionBytes = 1000000
+	defaultPrefetchChunks   = 64
+
+	// The largest value FoundationDB allows.
+	maxChunkSize = 100000
+
+	uploadIDSize = 16
+
+	// The number of upload records examined by each transaction of
+	// CollectGarbage.
+	garbagePageSize = 100
+)
+
+var (
+	// ErrNotFound is returned when an object does not exist.
+	ErrNotFound = errors.New("the object does not exist")
+
+	// ErrModified is returned when an object is replaced or deleted while it
+	// is being read.
+	ErrModified = errors.New("the object was modified while it was being read")
+
+	// ErrChecksumMismatch is returned when the data read from an object does
+	// not match its checksum.
+	ErrChecksumMismatch = errors.New("the object does not match its checksum")
+
+	// ErrAbandoned is returned when an upload is removed by CollectGarbage
+	// before it is committed.
+	ErrAbandoned = errors.New("the upload was abandoned")
+
+	errClosed = errors.New("the writer is closed")
+)
+
+// Options configures a Store.
+type Options struct {
+	// ChunkSize is the number of bytes stored under each key. A value of 0
+	// uses a chunk size of 10000 bytes. The chunk size of an object is stored
+	// with it, so it may be changed without affecting existing objects.
+	ChunkSize int
+
+	// TransactionBytes is the approximate number of bytes written or read by
+	// each transaction. A value of 0 uses a limit of 1MB.
+	TransactionBytes int
+
+	// PrefetchChunks is the number of chunks read by each range read of a
+	// Reader, and read ahead of the data returned by Read. A value of 0 reads
+	// 64 chunks at a time.
+	PrefetchChunks int
+}
+
+// Store stores objects in a subspace of a database.
+type Store struct {
+	db      fdb.Database
+	sub     subspace.Subspace
+	options Options
+}
+
+// New returns a Store that stores objects in sub. It returns an error if the
+// chunk size is larger than the largest value FoundationDB allows.
+func New(db fdb.Database, sub subspace.Subspace, options Options) (Store, error) {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = defaultChunkSize
+	}
+	if options.ChunkSize > maxChunkSize {
+		return Store{}, fmt.Errorf("chunk size %d is larger than the maximum value size of %d bytes", options.ChunkSize, maxChunkSize)
+	}
+	if options.TransactionBytes <= 0 {
+		options.TransactionBytes = defaultTransactionBytes
+	}
+	if options.TransactionBytes < options.ChunkSize {
+		options.TransactionBytes = options.ChunkSize
+	}
+	if options.PrefetchChunks <= 0 {
+		options.PrefetchChunks = defaultPrefetchChunks
+	}
+	return Store{db: db, sub: sub, options: options}, nil
+}
+
+// metadata describes a committed object.
+type metadata struct {
+	uploadID  []byte
+	size      int64
+	chunkSize int64
+	checksum  []byte
+}
+
+func (m metadata) pack() []byte {
+	return tuple.Tuple{m.uploadID, m.size, m.chunkSize, m.checksum}.Pack()
+}
+
+func unpackMetadata(b []byte) (metadata, error) {
+	t, err := tuple.Unpack(b)
+	if err != nil {
+		return metadata{}, err
+	}
+	if len(t) != 4 {
+		return metadata{}, fmt.Errorf("invalid object metadata %v", t)
+	}
+	id, ok1 := t[0].([]byte)
+	size, ok2 := t[1].(int64)
+	chunkSize, ok3 := t[2].(int64)
+	checksum, ok4 := t[3].([]byte)
+	if !ok1 || !ok2 || !ok3 || !ok4 || chunkSize <= 0 {
+		return metadata{}, fmt.Errorf("invalid object metadata %v", t)
+	}
+	return metadata{uploadID: id, size: size, chunkSize: chunkSize, checksum: checksum}, nil
+}
+
+func (s Store) metaKey(name string) fdb.Key {
+	return s.sub.Pack(tuple.Tuple{"meta", name})
+}
+
+func (s Store) chunkKey(id []byte, offset int64) fdb.Key {
+	return s.sub.Pack(tuple.Tuple{"data", id, offset})
+}
+
+func (s Store) chunks(id []byte) subspace.Subspace {
+	return s.sub.Sub("data", id)
+}
+
+func (s Store) uploadKey(id []byte) fdb.Key {
+	return s.sub.Pack(tuple.Tuple{"upload", id})
+}
+
+func (s Store) getMetadata(rtr fdb.ReadTransaction, name string) (metadata, bool, error) {
+	b, err := rtr.Get(s.metaKey(name)).Get()
+	if err != nil || b == nil {
+		return metadata{}, false, err
+	}
+	m, err := unpackMetadata(b)
+	return m, err == nil, err
+}
+
+// Create starts uploading the object name. The object is replaced by the data
+// written to the returned Writer when the Writer is closed.
+func (s Store) Create(ctx context.Context, name string) (*Writer, error) {
+	id := make([]byte, uploadIDSize)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	return &Writer{
+		ctx:   ctx,
+		store: s,
+		name:  name,
+		id:    id,
+		start: time.Now(),
+		hash:  sha256.New(),
+	}, nil
+}
+
+// Put replaces the object name with the data read from r.
+func (s Store) Put(ctx context.Context, name string, r io.Reader) error {
+	w, err := s.Create(ctx, name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(w, r); err != nil {
+		w.Abort()
+		return err
+	}
+	return w.Close()
+}
+
+// Open returns a Reader for the object name, or ErrNotFound if it does not
+// exist. The Reader reads the object as it was when it was opened.
+func (s Store) Open(ctx context.Context, name string) (*Reader, error) {
+	ret, err := s.db.ReadTransactContext(ctx, func(rtr fdb.ReadTransaction) (interface{}, error) {
+		m, ok, err := s.getMetadata(rtr, name)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return m, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{ctx: ctx, store: s, meta: ret.(metadata), hash: sha256.New()}, nil
+}
+
+// Delete removes the object name. Deleting an object that does not exist is
+// not an error.
+func (s Store) Delete(ctx context.Context, name string) error {
+	_, err := s.db.TransactContext(ctx, func(tr fdb.Transaction) (interface{}, error) {
+		m, ok, err := s.getMetadata(tr, name)
+		if err != nil || !ok {
+			return nil, err
+		}
+		tr.ClearRange(s.chunks(m.uploadID))
+		tr.Clear(s.metaKey(name))
+		return nil, nil
+	})
+	return err
+}
+
+// CollectGarbage removes the chunks of uploads that were started more than
+// olderThan ago and have not been committed, and returns the number of uploads
+// removed. olderThan should be much longer than an upload is expected to take:
+// closing a Writer whose upload has been removed fails with ErrAbandoned.
+// Uploads are examined and removed in batches, each in its own transaction, so
+// if an error is returned some uploads may already have been removed.
+func (s Store) CollectGarbage(ctx context.Context, olderThan time.Duration) (int, error) {
+	cutoff := time.Now().Add(-olderThan).UnixNano()
+	uploads := s.sub.Sub("upload")
+	begin, end := uploads.FDBRangeKeys()
+
+	type page struct {
+		removed int
+		next    fdb.Key
+	}
+
+	removed := 0
+	for {
+		// The upload records are read with snapshot reads, so that only the
+		// records that are removed conflict with uploads being committed
+		ret, err := s.db.TransactContext(ctx, func(tr fdb.Transaction) (interface{}, error) {
+			kr := fdb.KeyRange{Begin: begin, End: end}
+			kvs, err := tr.Snapshot().GetRange(kr, fdb.RangeOptions{Limit: garbagePageSize}).GetSliceWithError()
+			if err != nil {
+				return nil, err
+			}
+
+			p := page{}
+			if len(kvs) == garbagePageSize {
+				p.next = append(append(fdb.Key{}, kvs[len(kvs)-1].Key...), 0x00)
+			}
+			for _, kv := range kvs {
+				key, err := uploads.Unpack(kv.Key)
+				if err != nil || len(key) != 1 {
+					continue
+				}
+				id, ok := key[0].([]byte)
+				if !ok {
+					continue
+				}
+				value, err := tuple.Unpack(kv.Value)
+				if err != nil || len(value) != 2 {
+					continue
+				}
+				if started, ok := value[1].(int64); !ok || started > cutoff {
+					continue
+				}
+
+				if err := tr.AddReadConflictKey(kv.Key); err != nil {
+					return nil, err
+				}
+				tr.ClearRange(s.chunks(id))
+				tr.Clear(kv.Key)
+				p.removed++
+			}
+			return p, nil
+		})
+		if err != nil {
+			return removed, err
+		}
+
+		p := ret.(page)
+		removed += p.removed
+		if p.next == nil {
+			return removed, nil
+		}
+		begin = p.next
+	}
+}
+
+// Writer uploads an object. Data written to a Writer is buffered until enough
+// has been written to fill a transaction, and the object is only visible to
+// readers once the Writer is closed. A Writer must not be used concurrently.
+type Writer struct {
+	ctx   context.Context
+	store Store
+	name  string
+	id    []byte
+	start time.Time
+
+	buf        []byte
+	offset     int64
+	hash       hash.Hash
+	registered bool
+	err        error
+	closed     bool
+}
+
+// Write buffers p, writing chunks of the object to the database whenever
+// enough data has been buffered to fill a transaction.
+func (w *Writer) Write(p []byte) (int, error) {
+	if w.closed {
+		return 0, errClosed
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	w.hash.Write(p)
+	w.buf = append(w.buf, p...)
+
+	chunkSize := w.store.options.ChunkSize
+	batch := w.store.options.TransactionBytes / chunkSize * chunkSize
+	for len(w.buf) >= batch {
+		if err := w.flush(batch); err != nil {
+			w.err = err
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// setChunks sets the chunks of the first n buffered bytes.
+func (w *Writer) setChunks(tr fdb.Transaction, n int) {
+	chunkSize := w.store.options.ChunkSize
+	for i := 0; i < n; i += chunkSize {
+		end := min(i+chunkSize, n)
+		tr.Set(w.store.chunkKey(w.id, w.offset+int64(i)), w.buf[i:end])
+	}
+}
+
+// flush writes the first n buffered bytes in a single transaction, recording
+// the upload so that it can be garbage collected if it is never committed.
+func (w *Writer) flush(n int) error {
+	_, err := w.store.db.TransactContext(w.ctx, func(tr fdb.Transaction) (interface{}, error) {
+		if w.registered {
+			// Reading the upload record conflicts with CollectGarbage removing it
+			v, err := tr.Get(w.store.uploadKey(w.id)).Get()
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return nil, ErrAbandoned
+			}
+		} else {
+			tr.Set(w.store.uploadKey(w.id), tuple.Tuple{w.name, w.start.UnixNano()}.Pack())
+		}
+		w.setChunks(tr, n)
+		return nil, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	w.registered = true
+	w.offset += int64(n)
+	w.buf = append(w.buf[:0], w.buf[n:]...)
+	return nil
+}
+
+// Close writes the remaining buffered data and commits the object, replacing
+// any existing object of the same name. If the upload fails, the chunks
+// already written are left for CollectGarbage to remove.
+func (w *Writer) Close() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	if w.err != nil {
+		return w.err
+	}
+
+	meta := metadata{
+		uploadID:  w.id,
+		size:      w.offset + int64(len(w.buf)),
+		chunkSize: int64(w.store.options.ChunkSize),
+		checksum:  w.hash.Sum(nil),
+	}
+
+	_, err := w.store.db.TransactContext(w.ctx, func(tr fdb.Transaction) (interface{}, error) {
+		old, exists, err := w.store.getMetadata(tr, w.name)
+		if err != nil {
+			return nil, err
+		}
+		if exists && bytes.Equal(old.uploadID, w.id) {
+			// An earlier attempt committed but reported commit_unknown_result
+			return nil, nil
+		}
+
+		if w.registered {
+			v, err := tr.Get(w.store.uploadKey(w.id)).Get()
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				return nil, ErrAbandoned
+			}
+			tr.Clear(w.store.uploadKey(w.id))
+		}
+		if exists {
+			tr.ClearRange(w.store.chunks(old.uploadID))
+		}
+
+		w.setChunks(tr, len(w.buf))
+		tr.Set(w.store.metaKey(w.name), meta.pack())
+		return nil, nil
+	})
+	w.err = err
+	return err
+}
+
+// Abort discards the upload, removing any chunks already written. The object
+// is left unchanged.
+func (w *Writer) Abort() error {
+	if w.closed {
+		return w.err
+	}
+	w.closed = true
+	w.err = errClosed
+	if !w.registered {
+		return nil
+	}
+
+	_, err := w.store.db.TransactContext(w.ctx, func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(w.store.chunks(w.id))
+		tr.Clear(w.store.uploadKey(w.id))
+		return nil, nil
+	})
+	return err
+}
+
+// prefetch is the result of reading a range of chunks ahead of Read.
+type prefetch struct {
+	offset int64
+	data   []byte
+	err    error
+}
+
+// Reader reads an object. Read streams the object from the beginning, reading
+// PrefetchChunks chunks ahead of the data returned, and verifies the checksum
+// of the object once the end is reached. ReadAt reads any part of the object
+// but does not verify its checksum.
+//
+// Each range of chunks is read in a separate transaction. If the object is
+// replaced or deleted while it is being read, reads fail with ErrModified. A
+// Reader must not be used concurrently, except for calls to ReadAt.
+type Reader struct {
+	ctx   context.Context
+	store Store
+	meta  metadata
+
+	offset  int64
+	buf     []byte
+	bufOff  int64
+	next    chan prefetch
+	hash    hash.Hash
+	eof     bool
+	readErr error
+}
+
+// Size returns the size of the object in bytes.
+func (r *Reader) Size() int64 {
+	return r.meta.size
+}
+
+// Checksum returns the SHA-256 checksum of the object.
+func (r *Reader) Checksum() []byte {
+	return r.meta.checksum
+}
+
+// fetch reads the object from offset, which must be the start of a chunk, to
+// end.
+func (r *Reader) fetch(offset, end int64) ([]byte, error) {
+	chunks := r.store.chunks(r.meta.uploadID)
+	kr := fdb.KeyRange{
+		Begin: r.store.chunkKey(r.meta.uploadID, offset),
+		End:   r.store.chunkKey(r.meta.uploadID, end),
+	}
+	count := (end - offset + r.meta.chunkSize - 1) / r.meta.chunkSize
+	options := fdb.RangeOptions{Limit: int(count), Mode: fdb.StreamingModeExact}
+
+	ret, err := r.store.db.ReadTransactContext(r.ctx, func(rtr fdb.ReadTransaction) (interface{}, error) {
+		kvs, err := rtr.GetRange(kr, options).GetSliceWithError()
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, 0, end-offset)
+		for _, kv := range kvs {
+			key, err := chunks.Unpack(kv.Key)
+			if err != nil || len(key) != 1 || key[0] != offset+int64(len(data)) {
+				return nil, ErrModified
+			}
+			data = append(data, kv.Value...)
+		}
+		return data, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := ret.([]byte)
+	if int64(len(data)) < end-offset {
+		return nil, ErrModified
+	}
+	return data[:end-offset], nil
+}
+
+// window returns the chunk-aligned range of the object read by each range
+// read starting at offset.
+func (r *Reader) window(offset int64) (int64, int64) {
+	chunkSize := r.meta.chunkSize
+	start := offset / chunkSize * chunkSize
+	chunks := int64(r.store.options.PrefetchChunks)
+	if limit := int64(r.store.options.TransactionBytes) / chunkSize; limit < chunks {
+		chunks = max(limit, 1)
+	}
+	return start, min(start+chunks*chunkSize, r.meta.size)
+}
+
+func (r *Reader) startPrefetch(offset int64) {
+	ch := make(chan prefetch, 1)
+	r.next = ch
+	go func() {
+		start, end := r.window(offset)
+		data, err := r.fetch(start, end)
+		ch <- prefetch{offset: start, data: data, err: err}
+	}()
+}
+
+// Read reads the next len(p) bytes of the object. It returns ErrChecksumMismatch
+// instead of io.EOF if the data read does not match the checksum of the object.
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.readErr != nil {
+		return 0, r.readErr
+	}
+	if r.offset >= r.meta.size {
+		if !r.eof {
+			r.eof = true
+			if !bytes.Equal(r.hash.Sum(nil), r.meta.checksum) {
+				r.readErr = ErrChecksumMismatch
+				return 0, r.readErr
+			}
+		}
+		r.readErr = io.EOF
+		return 0, io.EOF
+	}
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if r.offset >= r.bufOff+int64(len(r.buf)) {
+		if r.next == nil {
+			r.startPrefetch(r.offset)
+		}
+		result := <-r.next
+		r.next = nil
+		if result.err != nil {
+			r.readErr = result.err
+			return 0, r.readErr
+		}
+		r.buf, r.bufOff = result.data, result.offset
+		if end := r.bufOff + int64(len(r.buf)); end < r.meta.size {
+			r.startPrefetch(end)
+		}
+	}
+
+	n := copy(p, r.buf[r.offset-r.bufOff:])
+	r.hash.Write(p[:n])
+	r.offset += int64(n)
+	return n, nil
+}
+
+// ReadAt reads len(p) bytes of the object starting at off. It reads the chunks
+// covering the requested range directly, without using the data prefetched by
+// Read, and may be called concurrently with other calls to ReadAt.
+func (r *Reader) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errors.New("negative offset")
+	}
+
+	n := 0
+	for n < len(p) && off < r.meta.size {
+		start, end := r.window(off)
+		end = min(end, off+int64(len(p)-n))
+		data, err := r.fetch(start, end)
+		if err != nil {
+			return n, err
+		}
+		copied := copy(p[n:], data[off-start:])
+		n += copied
+		off += int64(copied)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+var (
+	_ io.Reader   = (*Reader)(nil)
+	_ io.ReaderAt = (*Reader)(nil)
+	_ io.Writer   = (*Writer)(nil)
+)
@@ -0,0 +1,94 @@
+package blob
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/subspace"
+)
+
+func TestMetadata(t *testing.T) {
+	m := metadata{
+		uploadID:  []byte("0123456789abcdef"),
+		size:      123456,
+		chunkSize: 10000,
+		checksum:  []byte{0xde, 0xad, 0xbe, 0xef},
+	}
+
+	got, err := unpackMetadata(m.pack())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.uploadID, m.uploadID) || got.size != m.size || got.chunkSize != m.chunkSize || !bytes.Equal(got.checksum, m.checksum) {
+		t.Errorf("unpackMetadata returned %+v, expected %+v", got, m)
+	}
+
+	if _, err := unpackMetadata([]byte("not a tuple")); err == nil {
+		t.Error("expected invalid metadata to be rejected")
+	}
+}
+
+func TestNew(t *testing.T) {
+	var db fdb.Database
+	sub := subspace.Sub("blobs")
+
+	s, err := New(db, sub, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.options.ChunkSize != defaultChunkSize || s.options.TransactionBytes != defaultTransactionBytes || s.options.PrefetchChunks != defaultPrefetchChunks {
+		t.Errorf("unexpected default options %+v", s.options)
+	}
+
+	if _, err := New(db, sub, Options{ChunkSize: maxChunkSize + 1}); err == nil {
+		t.Error("expected a chunk size larger than the maximum value size to be rejected")
+	}
+}
+
+func TestChunkKeysOrder(t *testing.T) {
+	s, err := New(fdb.Database{}, subspace.Sub("blobs"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := []byte("id")
+	prev := s.chunkKey(id, 0)
+	for _, offset := range []int64{10000, 20000, 100000, 1 << 40} {
+		key := s.chunkKey(id, offset)
+		if bytes.Compare(prev, key) >= 0 {
+			t.Errorf("chunk at offset %d does not sort after the previous chunk", offset)
+		}
+		if !bytes.HasPrefix(key, s.chunks(id).Bytes()) {
+			t.Errorf("chunk at offset %d is outside the upload's subspace", offset)
+		}
+		prev = key
+	}
+}
+
+func TestReaderWindow(t *testing.T) {
+	s, err := New(fdb.Database{}, subspace.Sub("blobs"), Options{ChunkSize: 100, TransactionBytes: 1000, PrefetchChunks: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &Reader{store: s, meta: metadata{size: 950, chunkSize: 100}}
+
+	testCases := []struct {
+		offset, start, end int64
+	}{
+		{0, 0, 400},
+		{150, 100, 500},
+		{700, 700, 950},
+	}
+	for _, tc := range testCases {
+		if start, end := r.window(tc.offset); start != tc.start || end != tc.end {
+			t.Errorf("window(%d) = %d, %d, expected %d, %d", tc.offset, start, end, tc.start, tc.end)
+		}
+	}
+
+	// The chunk size stored with an object takes precedence over the store's
+	r.meta.chunkSize = 500
+	if start, end := r.window(600); start != 500 || end != 950 {
+		t.Errorf("window(600) = %d, %d, expected 500, 950", start, end)
+	}
+}
@@ -0,0 +1,389 @@
+/*
+ * bulk.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"runtime"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBulkBatchBytes    = 1000000
+	defaultBulkBatchDuration = time.Second
+
+	// The longest a transaction of a BulkWriter is kept open before it is
+	// committed, well within the five second transaction time limit.
+	bulkTransactionDuration = 2 * time.Second
+)
+
+var errBulkWriterClosed = errors.New("bulk writer is closed")
+
+// BulkWriterOptions configures a BulkWriter.
+type BulkWriterOptions struct {
+	// MaxBatchBytes is the approximate size of each transaction, as reported
+	// by (Transaction).GetApproximateSize, which includes the overhead of each
+	// mutation and its conflict ranges as well as the keys and values. Write
+	// buffers batches of about MaxBatchBytes bytes of keys and values, and a
+	// batch is committed in as many transactions as are needed to keep each
+	// within MaxBatchBytes. It should be well below the transaction size limit
+	// of 10MB, or any smaller limit set with SetSizeLimit. A value of 0 uses a
+	// limit of 1MB, which is the largest size recommended for good
+	// performance.
+	MaxBatchBytes int64
+
+	// MaxBatchDuration is the longest time key-value pairs are buffered before
+	// they are committed, even if the batch is not full. A value of 0 uses a
+	// duration of one second.
+	MaxBatchDuration time.Duration
+
+	// Concurrency is the maximum number of transactions committed at the same
+	// time. A value of 0 uses runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// OnProgress, if set, is called after each batch has been committed,
+	// which may take several transactions. It is called from the goroutines
+	// committing batches, but never concurrently, and must not call methods
+	// of the BulkWriter other than Progress.
+	OnProgress func(BulkProgress)
+}
+
+// BulkProgress describes the progress of a BulkWriter.
+type BulkProgress struct {
+	// KeysWritten is the number of key-value pairs committed.
+	KeysWritten int64
+
+	// BytesWritten is the approximate size of the committed transactions,
+	// estimated in the same way as by (Transaction).GetApproximateSize.
+	BytesWritten int64
+
+	// Checkpoint is the last key of the longest prefix of the key-value pairs
+	// passed to Write that has been committed, or nil if no prefix has been
+	// committed. If the key-value pairs are written in key order, an
+	// interrupted load may be resumed from the first key after Checkpoint.
+	Checkpoint Key
+}
+
+type bulkBatch struct {
+	seq uint64
+	kvs []KeyValue
+}
+
+// BulkWriter loads large numbers of key-value pairs into a database, dividing
+// them into transactions that stay within the limits on transaction size and
+// duration. Batches of consecutive key-value pairs are committed concurrently,
+// so that writes to different key ranges proceed in parallel.
+//
+// Each batch is buffered until it is committed, and each of its transactions
+// is retried as a whole if it fails. Since the key-value pairs are only set,
+// retrying a transaction whose commit result is unknown is safe. A transaction
+// is committed once it has been open for two seconds, even if it is not full,
+// so that it does not fail with transaction_too_old. A batch that fails with
+// transaction_too_large is split in two and each half is committed separately.
+//
+// Write and Close must not be called concurrently. Key-value pairs passed to
+// Write must not be modified until they have been committed.
+type BulkWriter struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	db      Database
+	options BulkWriterOptions
+
+	// batchMu protects the current batch, which is flushed either by Write or
+	// by batchTimer once it has been buffered for MaxBatchDuration.
+	batchMu    sync.Mutex
+	batch      []KeyValue
+	batchBytes int64
+	batchTimer *time.Timer
+	seq        uint64
+	batches    chan bulkBatch
+	workers    sync.WaitGroup
+	closed     bool
+
+	progressMu sync.Mutex
+
+	mu        sync.Mutex
+	err       error
+	progress  BulkProgress
+	committed map[uint64]Key
+	next      uint64
+}
+
+// NewBulkWriter returns a BulkWriter that writes to db until ctx is done. The
+// BulkWriter must be closed with Close once all key-value pairs have been
+// written.
+func NewBulkWriter(ctx context.Context, db Database, options BulkWriterOptions) *BulkWriter {
+	if options.MaxBatchBytes <= 0 {
+		options.MaxBatchBytes = defaultBulkBatchBytes
+	}
+	if options.MaxBatchDuration <= 0 {
+		options.MaxBatchDuration = defaultBulkBatchDuration
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w := &BulkWriter{
+		ctx:       ctx,
+		cancel:    cancel,
+		db:        db,
+		options:   options,
+		batches:   make(chan bulkBatch, options.Concurrency),
+		committed: make(map[uint64]Key),
+	}
+
+	for i := 0; i < options.Concurrency; i++ {
+		w.workers.Add(1)
+		go w.work()
+	}
+	return w
+}
+
+// Write adds key-value pairs to the current batch, which is committed once it
+// is full or has been buffered for MaxBatchDuration. Write blocks while
+// Concurrency batches are waiting to be committed. It returns the first error
+// encountered while committing a batch, after which no further batches are
+// committed.
+func (w *BulkWriter) Write(kvs ...KeyValue) error {
+	w.batchMu.Lock()
+	defer w.batchMu.Unlock()
+
+	if w.closed {
+		return errBulkWriterClosed
+	}
+
+	for _, kv := range kvs {
+		if len(w.batch) == 0 {
+			seq := w.seq
+			w.batchTimer = time.AfterFunc(w.options.MaxBatchDuration, func() {
+				w.flushStale(seq)
+			})
+		}
+		w.batch = append(w.batch, kv)
+		w.batchBytes += int64(len(kv.Key) + len(kv.Value))
+
+		if w.batchBytes >= w.options.MaxBatchBytes {
+			if err := w.flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return w.Err()
+}
+
+// WriteAll writes the key-value pairs produced by seq, stopping at the first
+// error produced by seq or returned by Write.
+func (w *BulkWriter) WriteAll(seq iter.Seq2[KeyValue, error]) error {
+	for kv, err := range seq {
+		if err != nil {
+			return err
+		}
+		if err := w.Write(kv); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close commits any buffered key-value pairs and waits for all batches to be
+// committed. It returns the first error encountered while committing a batch.
+func (w *BulkWriter) Close() error {
+	w.batchMu.Lock()
+	if !w.closed {
+		w.flush()
+		w.closed = true
+		close(w.batches)
+	}
+	w.batchMu.Unlock()
+
+	w.workers.Wait()
+	w.cancel()
+	return w.Err()
+}
+
+// Err returns the first error encountered while committing a batch, or nil.
+func (w *BulkWriter) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}
+
+// Progress returns the progress of the BulkWriter so far.
+func (w *BulkWriter) Progress() BulkProgress {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.progress
+}
+
+func (w *BulkWriter) fail(err error) {
+	w.mu.Lock()
+	if w.err == nil {
+		w.err = err
+	}
+	w.mu.Unlock()
+	w.cancel()
+}
+
+// flushStale flushes the current batch if it is still the batch numbered seq,
+// which has been buffered for MaxBatchDuration.
+func (w *BulkWriter) flushStale(seq uint64) {
+	w.batchMu.Lock()
+	defer w.batchMu.Unlock()
+
+	if !w.closed && w.seq == seq {
+		w.flush()
+	}
+}
+
+// flush passes the current batch to the workers. w.batchMu must be held.
+func (w *BulkWriter) flush() error {
+	if len(w.batch) == 0 {
+		return w.Err()
+	}
+	w.batchTimer.Stop()
+
+	b := bulkBatch{seq: w.seq, kvs: w.batch}
+	w.seq++
+	w.batch = nil
+	w.batchBytes = 0
+
+	select {
+	case w.batches <- b:
+	case <-w.ctx.Done():
+		w.fail(w.ctx.Err())
+	}
+	return w.Err()
+}
+
+func (w *BulkWriter) work() {
+	defer w.workers.Done()
+
+	for b := range w.batches {
+		// Once a batch has failed, the remaining batches are discarded
+		if w.ctx.Err() != nil {
+			continue
+		}
+
+		size, err := w.commit(b.kvs)
+		if err != nil {
+			w.fail(err)
+			continue
+		}
+		w.complete(b, size)
+	}
+}
+
+// commit writes kvs in as many transactions as are needed to keep the
+// approximate size of each within MaxBatchBytes and its duration within
+// bulkTransactionDuration, and returns the approximate size of the
+// transactions.
+func (w *BulkWriter) commit(kvs []KeyValue) (int64, error) {
+	type written struct {
+		n    int
+		size int64
+	}
+
+	var total int64
+	for len(kvs) > 0 {
+		ret, err := w.db.TransactContext(w.ctx, func(tr Transaction) (interface{}, error) {
+			start := time.Now()
+
+			// The size of the transaction is estimated locally as each pair
+			// is set, in the same way as by GetApproximateSize, which is only
+			// called as the estimate nears MaxBatchBytes. Each check halves the
+			// distance to MaxBatchBytes at which the next check is made.
+			var size int64
+			check := w.options.MaxBatchBytes * 9 / 10
+			n := 0
+			for n < len(kvs) && size < w.options.MaxBatchBytes && time.Since(start) < bulkTransactionDuration {
+				kv := kvs[n]
+				tr.Set(kv.Key, kv.Value)
+				n++
+
+				// The mutation and its write conflict range
+				size += int64(3*len(kv.Key) + len(kv.Value) + 1)
+				if size >= check {
+					var err error
+					if size, err = tr.GetApproximateSize().Get(); err != nil {
+						return nil, err
+					}
+					check = size + (w.options.MaxBatchBytes-size)/2
+				}
+			}
+			return written{n, size}, nil
+		})
+
+		if errors.Is(err, ErrTransactionTooLarge) && len(kvs) > 1 {
+			mid := len(kvs) / 2
+			first, err := w.commit(kvs[:mid])
+			if err != nil {
+				return 0, err
+			}
+			second, err := w.commit(kvs[mid:])
+			return total + first + second, err
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		wr := ret.(written)
+		total += wr.size
+		kvs = kvs[wr.n:]
+	}
+	return total, nil
+}
+
+// complete records that b has been committed, and advances the checkpoint past
+// every batch up to which all batches have been committed.
+func (w *BulkWriter) complete(b bulkBatch, size int64) {
+	// progressMu serializes calls to OnProgress, and is held while w.progress
+	// is updated so that the callback sees the progress in order. w.mu is
+	// released before the callback runs, since it may call Progress.
+	w.progressMu.Lock()
+	defer w.progressMu.Unlock()
+
+	w.mu.Lock()
+	w.progress.KeysWritten += int64(len(b.kvs))
+	w.progress.BytesWritten += size
+
+	w.committed[b.seq] = b.kvs[len(b.kvs)-1].Key.FDBKey()
+	for {
+		key, ok := w.committed[w.next]
+		if !ok {
+			break
+		}
+		delete(w.committed, w.next)
+		w.progress.Checkpoint = key
+		w.next++
+	}
+	progress := w.progress
+	w.mu.Unlock()
+
+	if w.options.OnProgress != nil {
+		w.options.OnProgress(progress)
+	}
+}
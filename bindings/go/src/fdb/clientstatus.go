@@ -0,0 +1,160 @@
+/*
+ * clientstatus.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// ProtocolVersion is the version of the protocol spoken by a FoundationDB
+// client or server, as returned by (Database).GetServerProtocol. Processes can
+// communicate only if their protocol versions are compatible.
+type ProtocolVersion uint64
+
+// String returns the protocol version in hexadecimal, as it appears in status
+// reports.
+func (v ProtocolVersion) String() string {
+	return strconv.FormatUint(uint64(v), 16)
+}
+
+// UnmarshalJSON decodes a protocol version from the hexadecimal string used in
+// status reports.
+func (v *ProtocolVersion) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	n, err := strconv.ParseUint(s, 16, 64)
+	if err != nil {
+		return fmt.Errorf("invalid protocol version %q: %w", s, err)
+	}
+	*v = ProtocolVersion(n)
+	return nil
+}
+
+// ClientStatus is the client-side status report returned by
+// (Database).GetClientStatus, as parsed by ParseClientStatus. It describes the
+// multi-version client database and embeds the status of the version-specific
+// database it is currently using.
+type ClientStatus struct {
+	// Healthy reports whether the database has been initialized and its
+	// version-specific database is healthy.
+	Healthy bool `json:"Healthy"`
+
+	// InitializationState is one of "initializing", "initialization_failed",
+	// "created", "incompatible" or "closed".
+	InitializationState string `json:"InitializationState"`
+
+	// InitializationError is the error code with which initialization failed,
+	// or 0 if it did not.
+	InitializationError int `json:"InitializationError,omitempty"`
+
+	// ProtocolVersion is the protocol version of the cluster, or 0 if it has
+	// not yet been determined.
+	ProtocolVersion ProtocolVersion `json:"ProtocolVersion,omitempty"`
+
+	// ConnectionRecord is the cluster file name or connection string used to
+	// connect to the cluster.
+	ConnectionRecord string `json:"ConnectionRecord"`
+
+	// DatabaseStatus is the status of the version-specific database, or nil
+	// if it could not be retrieved.
+	DatabaseStatus *DatabaseClientStatus `json:"DatabaseStatus,omitempty"`
+
+	// ErrorRetrievingDatabaseStatus is the error code with which retrieving
+	// DatabaseStatus failed, or 0 if it did not.
+	ErrorRetrievingDatabaseStatus int `json:"ErrorRetrievingDatabaseStatus,omitempty"`
+
+	// AvailableClients describes the client libraries loaded by the
+	// multi-version client.
+	AvailableClients []ClientLibraryStatus `json:"AvailableClients"`
+}
+
+// ClientLibraryStatus describes a client library loaded by the multi-version
+// client.
+type ClientLibraryStatus struct {
+	ProtocolVersion ProtocolVersion `json:"ProtocolVersion"`
+	ReleaseVersion  string          `json:"ReleaseVersion"`
+	ThreadIndex     int             `json:"ThreadIndex"`
+}
+
+// DatabaseClientStatus is the status of the version-specific database within a
+// ClientStatus, and includes the server processes known to the client and the
+// state of its connections to them.
+type DatabaseClientStatus struct {
+	// Healthy reports whether the client is connected to a coordinator, knows
+	// of at least one GRV proxy and commit proxy, and has no failed
+	// connections.
+	Healthy bool `json:"Healthy"`
+
+	// InitializationError is the error code with which the database failed
+	// to initialize, or 0 if it did not.
+	InitializationError int `json:"InitializationError,omitempty"`
+
+	ClusterID            string                   `json:"ClusterID"`
+	Coordinators         []string                 `json:"Coordinators"`
+	CurrentCoordinator   string                   `json:"CurrentCoordinator,omitempty"`
+	GrvProxies           []string                 `json:"GrvProxies"`
+	CommitProxies        []string                 `json:"CommitProxies"`
+	StorageServers       []StorageServerStatus    `json:"StorageServers"`
+	Connections          []ClientConnectionStatus `json:"Connections"`
+	NumConnectionsFailed int                      `json:"NumConnectionsFailed"`
+}
+
+// StorageServerStatus identifies a storage server known to the client.
+type StorageServerStatus struct {
+	Address string `json:"Address"`
+	SSID    string `json:"SSID"`
+}
+
+// ClientConnectionStatus describes the connection of the client to a server
+// process. Times are in seconds.
+type ClientConnectionStatus struct {
+	Address string `json:"Address"`
+
+	// Status is one of "connected", "connecting", "disconnected" or
+	// "failed".
+	Status string `json:"Status"`
+
+	Compatible         bool            `json:"Compatible"`
+	ConnectFailedCount int             `json:"ConnectFailedCount"`
+	LastConnectTime    float64         `json:"LastConnectTime"`
+	PingCount          int             `json:"PingCount"`
+	PingTimeoutCount   int             `json:"PingTimeoutCount"`
+	BytesSampleTime    float64         `json:"BytesSampleTime"`
+	BytesReceived      int64           `json:"BytesReceived"`
+	BytesSent          int64           `json:"BytesSent"`
+	ProtocolVersion    ProtocolVersion `json:"ProtocolVersion,omitempty"`
+}
+
+// ParseClientStatus parses the JSON report returned by
+// (Database).GetClientStatus.
+func ParseClientStatus(data []byte) (ClientStatus, error) {
+	var st ClientStatus
+	if err := json.Unmarshal(data, &st); err != nil {
+		return ClientStatus{}, err
+	}
+	return st, nil
+}
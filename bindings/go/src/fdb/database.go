@@ -27,8 +27,10 @@ package fdb
 import "C"
 
 import (
+	"context"
 	"errors"
 	"runtime"
+	"time"
 )
 
 // ErrMultiVersionClientUnavailable is returned when the multi-version client API is unavailable.
@@ -50,6 +52,11 @@ type Database struct {
 	// database structs. We can't use clusterFile alone, since the default clusterFile
 	// would be an empty string.
 	isCached bool
+	// Retry policy applied by Transact and ReadTransact, see WithRetryPolicy.
+	retryPolicy *RetryPolicy
+	// Interceptors wrapping Transact and ReadTransact, see WithInterceptors.
+	// Held by pointer so that Database remains comparable.
+	interceptors *[]Interceptor
 	*database
 }
 
@@ -162,8 +169,75 @@ func (d Database) GetClientStatus() ([]byte, error) {
 	return b, nil
 }
 
-func retryable(wrapped func() (interface{}, error), onError func(Error) FutureNil) (ret interface{}, err error) {
-	for {
+// GetServerProtocol returns a future that is the protocol version of the
+// cluster, as reported by the coordinator the client is connected to. If
+// expected is non-zero, the future does not become ready until the protocol
+// version of the cluster differs from expected, which allows a client to wait
+// for the cluster to be upgraded.
+func (d Database) GetServerProtocol(expected uint64) FutureUint64 {
+	return &futureUint64{
+		future: newFutureWithDb(d.database, nil, C.fdb_database_get_server_protocol(d.ptr, C.uint64_t(expected))),
+	}
+}
+
+// MainThreadBusyness returns the fraction of time the client network thread
+// has recently spent busy, from 0 to 1. A value near 1 indicates that the
+// client is saturated and adding load to it will increase latency.
+func (d Database) MainThreadBusyness() float64 {
+	return float64(C.fdb_database_get_main_thread_busyness(d.ptr))
+}
+
+// CreateSnapshot returns a future that is ready once a snapshot of the cluster
+// has been taken, by running command on every process that holds data. The uid
+// identifies the snapshot and must be a 32 character hexadecimal string; if
+// the snapshot fails, the partial snapshots created with uid must be cleaned
+// up by the caller. The command must be a binary whitelisted by each process
+// with the --whitelist_binpath option, followed by its arguments.
+func (d Database) CreateSnapshot(uid, command string) FutureNil {
+	return &futureNil{
+		future: newFutureWithDb(
+			d.database,
+			nil,
+			C.fdb_database_create_snapshot(
+				d.ptr,
+				byteSliceToPtr([]byte(uid)),
+				C.int(len(uid)),
+				byteSliceToPtr([]byte(command)),
+				C.int(len(command)),
+			),
+		),
+	}
+}
+
+// ForceRecoveryWithDataLoss returns a future that is ready once the cluster
+// has been forced to recover into the datacenter with the given ID, which
+// becomes the primary datacenter. Mutations which were committed but not yet
+// replicated to that datacenter are lost. This is intended only for disaster
+// recovery when the primary datacenter has been lost.
+func (d Database) ForceRecoveryWithDataLoss(dcID string) FutureNil {
+	return &futureNil{
+		future: newFutureWithDb(
+			d.database,
+			nil,
+			C.fdb_database_force_recovery_with_data_loss(
+				d.ptr,
+				byteSliceToPtr([]byte(dcID)),
+				C.int(len(dcID)),
+			),
+		),
+	}
+}
+
+func retryable(ctx context.Context, tr Transaction, wrapped func() (interface{}, error)) (ret interface{}, err error) {
+	policy := tr.db.retryPolicy
+	start := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		// A done context stops the retry loop before the next attempt
+		if err = ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		ret, err = wrapped()
 
 		// No error means success!
@@ -171,11 +245,22 @@ func retryable(wrapped func() (interface{}, error), onError func(Error) FutureNi
 			return
 		}
 
+		// Errors caused by the context cancelling the transaction are
+		// reported as the context's error
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
 		// Check if the error chain contains an
 		// fdb.Error
 		var ep Error
 		if errors.As(err, &ep) {
-			processedErr := onError(ep).Get()
+			var processedErr error
+			if policy == nil {
+				processedErr = tr.OnError(ep).GetContext(ctx)
+			} else {
+				processedErr = policy.onError(ctx, tr, ep, attempt, start)
+			}
 			var newEp Error
 			if !errors.As(processedErr, &newEp) || newEp.Code != ep.Code {
 				// override original error only if not an Error or code changed
@@ -192,6 +277,17 @@ func retryable(wrapped func() (interface{}, error), onError func(Error) FutureNi
 	}
 }
 
+// cancelOnDone arranges for tr to be cancelled once ctx is done, failing all
+// of its outstanding futures and thereby unblocking the transactional function
+// and any commit in progress. The returned function must be called once tr is
+// no longer in use.
+func cancelOnDone(ctx context.Context, tr Transaction) (stop func() bool) {
+	if ctx.Done() == nil {
+		return func() bool { return true }
+	}
+	return context.AfterFunc(ctx, tr.Cancel)
+}
+
 // Transact runs a caller-provided function inside a retry loop, providing it
 // with a newly created Transaction. After the function returns, the Transaction
 // will be committed automatically. Any error during execution of the function
@@ -221,19 +317,69 @@ func (d Database) Transact(f func(Transaction) (interface{}, error)) (interface{
 		return nil, err
 	}
 
+	return transact(context.Background(), tr, f)
+}
+
+// TransactContext is like Transact, but stops retrying and returns ctx.Err()
+// once ctx is done. When ctx is done while the caller-provided function or the
+// commit is in progress, the Transaction is cancelled, causing all of its
+// outstanding futures to fail.
+//
+// As with any cancellation of a transaction during its commit, if ctx is done
+// while the Transaction is being committed, the Transaction may or may not
+// have been committed.
+func (d Database) TransactContext(ctx context.Context, f func(Transaction) (interface{}, error)) (interface{}, error) {
+	tr, err := d.CreateTransaction()
+	// Any error here is non-retryable
+	if err != nil {
+		return nil, err
+	}
+
+	return transact(ctx, tr, f)
+}
+
+// transact runs f against tr inside a retry loop, committing tr after each
+// successful invocation of f. It implements the retry semantics shared by
+// (Database).Transact and (Tenant).Transact.
+func transact(ctx context.Context, tr Transaction, f func(Transaction) (interface{}, error)) (ret interface{}, err error) {
+	stop := cancelOnDone(ctx, tr)
+	defer stop()
+
+	info := TransactInfo{Context: ctx, Transaction: tr}
+	attempt := 0
+
 	wrapped := func() (ret interface{}, err error) {
 		defer panicToError(&err)
 
-		ret, err = f(tr)
+		attempt++
+		ai := AttemptInfo{TransactInfo: info, Attempt: attempt}
 
-		if err == nil {
-			err = tr.Commit().Get()
-		}
+		err = intercept(tr.db.getInterceptors(), attemptHook, ai, func() (err error) {
+			defer panicToError(&err)
+
+			if p := tr.db.retryPolicy; p != nil && p.OnConflict != nil {
+				if err = tr.Options().SetReportConflictingKeys(); err != nil {
+					return
+				}
+			}
+
+			ret, err = f(tr)
+
+			if err == nil {
+				err = intercept(tr.db.getInterceptors(), commitHook, ai, tr.Commit().Get)
+			}
+
+			return
+		})
 
 		return
 	}
 
-	return retryable(wrapped, tr.OnError)
+	err = intercept(tr.db.getInterceptors(), transactHook, info, func() (err error) {
+		ret, err = retryable(ctx, tr, wrapped)
+		return
+	})
+	return
 }
 
 // ReadTransact runs a caller-provided function inside a retry loop, providing
@@ -266,18 +412,58 @@ func (d Database) ReadTransact(f func(ReadTransaction) (interface{}, error)) (in
 		return nil, err
 	}
 
+	return readTransact(context.Background(), tr, f)
+}
+
+// ReadTransactContext is like ReadTransact, but stops retrying and returns
+// ctx.Err() once ctx is done. When ctx is done while the caller-provided
+// function is in progress, the Transaction is cancelled, causing all of its
+// outstanding futures to fail.
+func (d Database) ReadTransactContext(ctx context.Context, f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	tr, err := d.CreateTransaction()
+	if err != nil {
+		// Any error here is non-retryable
+		return nil, err
+	}
+
+	return readTransact(ctx, tr, f)
+}
+
+// readTransact runs f against tr inside a retry loop without committing. It
+// implements the retry semantics shared by (Database).ReadTransact and
+// (Tenant).ReadTransact.
+func readTransact(ctx context.Context, tr Transaction, f func(ReadTransaction) (interface{}, error)) (ret interface{}, err error) {
+	stop := cancelOnDone(ctx, tr)
+	defer stop()
+
+	info := TransactInfo{Context: ctx, ReadOnly: true, Transaction: tr}
+	attempt := 0
+
 	wrapped := func() (ret interface{}, err error) {
 		defer panicToError(&err)
 
-		ret, err = f(tr)
+		attempt++
+		ai := AttemptInfo{TransactInfo: info, Attempt: attempt}
+
+		err = intercept(tr.db.getInterceptors(), attemptHook, ai, func() (err error) {
+			defer panicToError(&err)
 
-		// read-only transactions are not committed and will be destroyed automatically via GC,
-		// once all the futures go out of scope
+			ret, err = f(tr)
+
+			// read-only transactions are not committed and will be destroyed automatically via GC,
+			// once all the futures go out of scope
+
+			return
+		})
 
 		return
 	}
 
-	return retryable(wrapped, tr.OnError)
+	err = intercept(tr.db.getInterceptors(), transactHook, info, func() (err error) {
+		ret, err = retryable(ctx, tr, wrapped)
+		return
+	})
+	return
 }
 
 // Options returns a DatabaseOptions instance suitable for setting options
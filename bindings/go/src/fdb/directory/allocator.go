@@ -63,9 +63,9 @@ func windowSize(start int64) int64 {
 	return 8192
 }
 
-func (hca highContentionAllocator) allocate(tr fdb.Transaction, s subspace.Subspace) (subspace.Subspace, error) {
+func (hca highContentionAllocator) allocate(tr Transaction, s subspace.Subspace) (subspace.Subspace, error) {
 	for {
-		rr := tr.Snapshot().GetRange(hca.counters, fdb.RangeOptions{Limit: 1, Reverse: true})
+		rr := snapshot(tr).GetRange(hca.counters, fdb.RangeOptions{Limit: 1, Reverse: true})
 		kvs, err := rr.GetSliceWithError()
 		if err != nil {
 			return nil, err
@@ -88,13 +88,13 @@ func (hca highContentionAllocator) allocate(tr fdb.Transaction, s subspace.Subsp
 
 			if windowAdvanced {
 				tr.ClearRange(fdb.KeyRange{hca.counters, hca.counters.Sub(start)})
-				tr.Options().SetNextWriteNoWriteConflictRange()
+				setNextWriteNoWriteConflictRange(tr)
 				tr.ClearRange(fdb.KeyRange{hca.recent, hca.recent.Sub(start)})
 			}
 
 			// Increment the allocation count for the current window
 			tr.Add(hca.counters.Sub(start), oneBytes)
-			countFuture := tr.Snapshot().Get(hca.counters.Sub(start))
+			countFuture := snapshot(tr).Get(hca.counters.Sub(start))
 
 			allocatorMutex.Unlock()
 
@@ -132,9 +132,9 @@ func (hca highContentionAllocator) allocate(tr fdb.Transaction, s subspace.Subsp
 
 			allocatorMutex.Lock()
 
-			latestCounter := tr.Snapshot().GetRange(hca.counters, fdb.RangeOptions{Limit: 1, Reverse: true})
+			latestCounter := snapshot(tr).GetRange(hca.counters, fdb.RangeOptions{Limit: 1, Reverse: true})
 			candidateValue := tr.Get(key)
-			tr.Options().SetNextWriteNoWriteConflictRange()
+			setNextWriteNoWriteConflictRange(tr)
 			tr.Set(key, []byte(""))
 
 			allocatorMutex.Unlock()
@@ -37,6 +37,15 @@
 //
 // Directory operations are transactional. A byte slice layer option is used as
 // a metadata identifier when opening a directory.
+//
+// The functions and methods of this package take an fdb.Transactor or an
+// fdb.ReadTransactor. If it is also a Transaction or ReadTransaction of this
+// package, as an fdb.Transaction is, the directory operation performs its reads
+// and writes directly on it. Otherwise, as for an fdb.Database or fdb.Tenant,
+// the operation runs in a transaction created by its Transact or ReadTransact
+// method. A Transaction that is not an fdb.Transactor, such as a fake
+// transaction in a test, is passed with Using, and a ReadTransaction with
+// UsingRead; their results must not be used outside of this package.
 package directory
 
 import (
@@ -69,6 +78,10 @@ var (
 
 // Directory represents a subspace of keys in a FoundationDB database,
 // identified by a hierarchical path.
+//
+// The methods of Directory use the fdb.Transactor or fdb.ReadTransactor passed
+// to them as described in the package documentation, and accept the results of
+// Using and UsingRead.
 type Directory interface {
 	// CreateOrOpen opens the directory specified by path (relative to this
 	// Directory), and returns the directory and its contents as a
@@ -186,6 +199,9 @@ var root = NewDirectoryLayer(subspace.FromBytes([]byte{0xFE}), subspace.AllKeys(
 // as the layer; if layer is specified and the directory already exists, it is
 // compared against the layer specified when the directory was created, and an
 // error is returned if they differ.
+//
+// If t is a Transaction, including one passed with Using, the directory is
+// created or opened in t rather than in a new transaction.
 func CreateOrOpen(t fdb.Transactor, path []string, layer []byte) (DirectorySubspace, error) {
 	return root.CreateOrOpen(t, path, layer)
 }
@@ -197,6 +213,9 @@ func CreateOrOpen(t fdb.Transactor, path []string, layer []byte) (DirectorySubsp
 // If the byte slice layer is specified, it is compared against the layer
 // specified when the directory was created, and an error is returned if they
 // differ.
+//
+// If rt is a ReadTransaction, including one passed with UsingRead, the
+// directory is read in rt rather than in a new transaction.
 func Open(rt fdb.ReadTransactor, path []string, layer []byte) (DirectorySubspace, error) {
 	return root.Open(rt, path, layer)
 }
@@ -207,6 +226,9 @@ func Open(rt fdb.ReadTransactor, path []string, layer []byte) (DirectorySubspace
 //
 // If the byte slice layer is specified, it is recorded as the layer and will be
 // checked when opening the directory in the future.
+//
+// If t is a Transaction, including one passed with Using, the directory is
+// created in t rather than in a new transaction.
 func Create(t fdb.Transactor, path []string, layer []byte) (DirectorySubspace, error) {
 	return root.Create(t, path, layer)
 }
@@ -219,19 +241,26 @@ func Create(t fdb.Transactor, path []string, layer []byte) (DirectorySubspace, e
 //
 // There is no effect on the physical prefix of the given directory or on
 // clients that already have the directory open.
+//
+// If t is a Transaction, including one passed with Using, the directory is
+// moved in t rather than in a new transaction.
 func Move(t fdb.Transactor, oldPath []string, newPath []string) (DirectorySubspace, error) {
 	return root.Move(t, oldPath, newPath)
 }
 
 // Exists returns true if the directory at path (relative to the default root
-// directory) exists, and false otherwise.
+// directory) exists, and false otherwise. If rt is a ReadTransaction, including
+// one passed with UsingRead, the directory is looked up in rt rather than in a
+// new transaction.
 func Exists(rt fdb.ReadTransactor, path []string) (bool, error) {
 	return root.Exists(rt, path)
 }
 
 // List returns the names of the immediate subdirectories of the default root
 // directory as a slice of strings. Each string is the name of the last
-// component of a subdirectory's path.
+// component of a subdirectory's path. If rt is a ReadTransaction, including one
+// passed with UsingRead, the subdirectories are read in rt rather than in a new
+// transaction.
 func List(rt fdb.ReadTransactor, path []string) ([]string, error) {
 	return root.List(rt, path)
 }
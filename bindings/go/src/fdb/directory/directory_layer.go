@@ -71,7 +71,7 @@ func NewDirectoryLayer(nodeSS, contentSS subspace.Subspace, allowManualPrefixes
 	return dl
 }
 
-func (dl directoryLayer) createOrOpen(rtr fdb.ReadTransaction, tr *fdb.Transaction, path []string, layer []byte, prefix []byte, allowCreate, allowOpen bool) (DirectorySubspace, error) {
+func (dl directoryLayer) createOrOpen(rtr ReadTransaction, tr Transaction, path []string, layer []byte, prefix []byte, allowCreate, allowOpen bool) (DirectorySubspace, error) {
 	if err := dl.checkVersion(rtr, nil); err != nil {
 		return nil, err
 	}
@@ -120,7 +120,7 @@ func (dl directoryLayer) createOrOpen(rtr fdb.ReadTransaction, tr *fdb.Transacti
 	}
 
 	if prefix == nil {
-		newss, err := dl.allocator.allocate(*tr, dl.contentSS)
+		newss, err := dl.allocator.allocate(tr, dl.contentSS)
 		if err != nil {
 			return nil, fmt.Errorf("unable to allocate new directory prefix (%s)", err.Error())
 		}
@@ -131,7 +131,7 @@ func (dl directoryLayer) createOrOpen(rtr fdb.ReadTransaction, tr *fdb.Transacti
 
 		prefix = newss.Bytes()
 
-		pf, err := dl.isPrefixFree(rtr.Snapshot(), prefix)
+		pf, err := dl.isPrefixFree(snapshot(rtr), prefix)
 		if err != nil {
 			return nil, err
 		}
@@ -177,8 +177,8 @@ func (dl directoryLayer) createOrOpen(rtr fdb.ReadTransaction, tr *fdb.Transacti
 }
 
 func (dl directoryLayer) CreateOrOpen(t fdb.Transactor, path []string, layer []byte) (DirectorySubspace, error) {
-	r, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
-		return dl.createOrOpen(tr, &tr, path, layer, nil, true, true)
+	r, err := transact(t, func(tr Transaction) (interface{}, error) {
+		return dl.createOrOpen(tr, tr, path, layer, nil, true, true)
 	})
 	if err != nil {
 		return nil, err
@@ -187,8 +187,8 @@ func (dl directoryLayer) CreateOrOpen(t fdb.Transactor, path []string, layer []b
 }
 
 func (dl directoryLayer) Create(t fdb.Transactor, path []string, layer []byte) (DirectorySubspace, error) {
-	r, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
-		return dl.createOrOpen(tr, &tr, path, layer, nil, true, false)
+	r, err := transact(t, func(tr Transaction) (interface{}, error) {
+		return dl.createOrOpen(tr, tr, path, layer, nil, true, false)
 	})
 	if err != nil {
 		return nil, err
@@ -200,8 +200,8 @@ func (dl directoryLayer) CreatePrefix(t fdb.Transactor, path []string, layer []b
 	if prefix == nil {
 		prefix = []byte{}
 	}
-	r, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
-		return dl.createOrOpen(tr, &tr, path, layer, prefix, true, false)
+	r, err := transact(t, func(tr Transaction) (interface{}, error) {
+		return dl.createOrOpen(tr, tr, path, layer, prefix, true, false)
 	})
 	if err != nil {
 		return nil, err
@@ -210,7 +210,7 @@ func (dl directoryLayer) CreatePrefix(t fdb.Transactor, path []string, layer []b
 }
 
 func (dl directoryLayer) Open(rt fdb.ReadTransactor, path []string, layer []byte) (DirectorySubspace, error) {
-	r, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+	r, err := readTransact(rt, func(rtr ReadTransaction) (interface{}, error) {
 		return dl.createOrOpen(rtr, nil, path, layer, nil, false, true)
 	})
 	if err != nil {
@@ -220,7 +220,7 @@ func (dl directoryLayer) Open(rt fdb.ReadTransactor, path []string, layer []byte
 }
 
 func (dl directoryLayer) Exists(rt fdb.ReadTransactor, path []string) (bool, error) {
-	r, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+	r, err := readTransact(rt, func(rtr ReadTransaction) (interface{}, error) {
 		if err := dl.checkVersion(rtr, nil); err != nil {
 			return false, err
 		}
@@ -235,7 +235,7 @@ func (dl directoryLayer) Exists(rt fdb.ReadTransactor, path []string) (bool, err
 			if err != nil {
 				return false, err
 			}
-			return nc.Exists(rtr, node.getPartitionSubpath())
+			return nc.Exists(readTransactor(rtr), node.getPartitionSubpath())
 		}
 
 		return true, nil
@@ -247,7 +247,7 @@ func (dl directoryLayer) Exists(rt fdb.ReadTransactor, path []string) (bool, err
 }
 
 func (dl directoryLayer) List(rt fdb.ReadTransactor, path []string) ([]string, error) {
-	r, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+	r, err := readTransact(rt, func(rtr ReadTransaction) (interface{}, error) {
 		if err := dl.checkVersion(rtr, nil); err != nil {
 			return nil, err
 		}
@@ -262,7 +262,7 @@ func (dl directoryLayer) List(rt fdb.ReadTransactor, path []string) ([]string, e
 			if err != nil {
 				return nil, err
 			}
-			return nc.List(rtr, node.getPartitionSubpath())
+			return nc.List(readTransactor(rtr), node.getPartitionSubpath())
 		}
 
 		return dl.subdirNames(rtr, node.subspace)
@@ -278,8 +278,8 @@ func (dl directoryLayer) MoveTo(t fdb.Transactor, newAbsolutePath []string) (Dir
 }
 
 func (dl directoryLayer) Move(t fdb.Transactor, oldPath []string, newPath []string) (DirectorySubspace, error) {
-	r, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
-		if err := dl.checkVersion(tr, &tr); err != nil {
+	r, err := transact(t, func(tr Transaction) (interface{}, error) {
+		if err := dl.checkVersion(tr, tr); err != nil {
 			return nil, err
 		}
 
@@ -307,7 +307,7 @@ func (dl directoryLayer) Move(t fdb.Transactor, oldPath []string, newPath []stri
 			if err != nil {
 				return nil, err
 			}
-			return nnc.Move(tr, oldNode.getPartitionSubpath(), newNode.getPartitionSubpath())
+			return nnc.Move(transactor(tr), oldNode.getPartitionSubpath(), newNode.getPartitionSubpath())
 		}
 
 		if newNode.exists() {
@@ -340,8 +340,8 @@ func (dl directoryLayer) Move(t fdb.Transactor, oldPath []string, newPath []stri
 }
 
 func (dl directoryLayer) Remove(t fdb.Transactor, path []string) (bool, error) {
-	r, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
-		if err := dl.checkVersion(tr, &tr); err != nil {
+	r, err := transact(t, func(tr Transaction) (interface{}, error) {
+		if err := dl.checkVersion(tr, tr); err != nil {
 			return false, err
 		}
 
@@ -360,7 +360,7 @@ func (dl directoryLayer) Remove(t fdb.Transactor, path []string) (bool, error) {
 			if err != nil {
 				return false, err
 			}
-			return nc.(directoryPartition).Remove(tr, node.getPartitionSubpath())
+			return nc.(directoryPartition).Remove(transactor(tr), node.getPartitionSubpath())
 		}
 
 		if err := dl.removeRecursive(tr, node.subspace); err != nil {
@@ -376,7 +376,7 @@ func (dl directoryLayer) Remove(t fdb.Transactor, path []string) (bool, error) {
 	return r.(bool), nil
 }
 
-func (dl directoryLayer) removeRecursive(tr fdb.Transaction, node subspace.Subspace) error {
+func (dl directoryLayer) removeRecursive(tr Transaction, node subspace.Subspace) error {
 	nodes := dl.subdirNodes(tr, node)
 	for i := range nodes {
 		if err := dl.removeRecursive(tr, nodes[i]); err != nil {
@@ -399,7 +399,7 @@ func (dl directoryLayer) removeRecursive(tr fdb.Transaction, node subspace.Subsp
 	return nil
 }
 
-func (dl directoryLayer) removeFromParent(tr fdb.Transaction, path []string) {
+func (dl directoryLayer) removeFromParent(tr Transaction, path []string) {
 	parent := dl.find(tr, path[:len(path)-1])
 	tr.Clear(parent.subspace.Sub(_SUBDIRS, path[len(path)-1]))
 }
@@ -412,7 +412,7 @@ func (dl directoryLayer) GetPath() []string {
 	return dl.path
 }
 
-func (dl directoryLayer) subdirNames(rtr fdb.ReadTransaction, node subspace.Subspace) ([]string, error) {
+func (dl directoryLayer) subdirNames(rtr ReadTransaction, node subspace.Subspace) ([]string, error) {
 	sd := node.Sub(_SUBDIRS)
 
 	rr := rtr.GetRange(sd, fdb.RangeOptions{})
@@ -437,7 +437,7 @@ func (dl directoryLayer) subdirNames(rtr fdb.ReadTransaction, node subspace.Subs
 	return ret, nil
 }
 
-func (dl directoryLayer) subdirNodes(tr fdb.Transaction, node subspace.Subspace) []subspace.Subspace {
+func (dl directoryLayer) subdirNodes(tr Transaction, node subspace.Subspace) []subspace.Subspace {
 	sd := node.Sub(_SUBDIRS)
 
 	rr := tr.GetRange(sd, fdb.RangeOptions{})
@@ -454,7 +454,7 @@ func (dl directoryLayer) subdirNodes(tr fdb.Transaction, node subspace.Subspace)
 	return ret
 }
 
-func (dl directoryLayer) nodeContainingKey(rtr fdb.ReadTransaction, key []byte) (subspace.Subspace, error) {
+func (dl directoryLayer) nodeContainingKey(rtr ReadTransaction, key []byte) (subspace.Subspace, error) {
 	if bytes.HasPrefix(key, dl.nodeSS.Bytes()) {
 		return dl.rootNode, nil
 	}
@@ -480,7 +480,7 @@ func (dl directoryLayer) nodeContainingKey(rtr fdb.ReadTransaction, key []byte)
 	return nil, nil
 }
 
-func (dl directoryLayer) isPrefixFree(rtr fdb.ReadTransaction, prefix []byte) (bool, error) {
+func (dl directoryLayer) isPrefixFree(rtr ReadTransaction, prefix []byte) (bool, error) {
 	if len(prefix) == 0 {
 		return false, nil
 	}
@@ -506,7 +506,7 @@ func (dl directoryLayer) isPrefixFree(rtr fdb.ReadTransaction, prefix []byte) (b
 	return true, nil
 }
 
-func (dl directoryLayer) checkVersion(rtr fdb.ReadTransaction, tr *fdb.Transaction) error {
+func (dl directoryLayer) checkVersion(rtr ReadTransaction, tr Transaction) error {
 	version, err := rtr.Get(dl.rootNode.Sub([]byte("version"))).Get()
 	if err != nil {
 		return err
@@ -514,7 +514,7 @@ func (dl directoryLayer) checkVersion(rtr fdb.ReadTransaction, tr *fdb.Transacti
 
 	if version == nil {
 		if tr != nil {
-			dl.initializeDirectory(*tr)
+			dl.initializeDirectory(tr)
 		}
 		return nil
 	}
@@ -542,7 +542,7 @@ func (dl directoryLayer) checkVersion(rtr fdb.ReadTransaction, tr *fdb.Transacti
 	return nil
 }
 
-func (dl directoryLayer) initializeDirectory(tr fdb.Transaction) {
+func (dl directoryLayer) initializeDirectory(tr Transaction) {
 	buf := new(bytes.Buffer)
 
 	// bytes.Buffer claims that Write will always return a nil error, which
@@ -588,7 +588,7 @@ func (dl directoryLayer) nodeWithPrefix(prefix []byte) subspace.Subspace {
 	return dl.nodeSS.Sub(prefix)
 }
 
-func (dl directoryLayer) find(rtr fdb.ReadTransaction, path []string) *node {
+func (dl directoryLayer) find(rtr ReadTransaction, path []string) *node {
 	n := &node{dl.rootNode, []string{}, path, nil}
 	for i := range path {
 		n = &node{dl.nodeWithPrefix(rtr.Get(n.subspace.Sub(_SUBDIRS, path[i])).MustGet()), path[:i+1], path, nil}
@@ -606,7 +606,7 @@ func (dl directoryLayer) partitionSubpath(lpath, rpath []string) []string {
 	return r
 }
 
-func isRangeEmpty(rtr fdb.ReadTransaction, r fdb.Range) bool {
+func isRangeEmpty(rtr ReadTransaction, r fdb.Range) bool {
 	kvs := rtr.GetRange(r, fdb.RangeOptions{Limit: 1}).GetSliceOrPanic()
 
 	return len(kvs) == 0
@@ -0,0 +1,167 @@
+/*
+ * transaction.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Directory Layer
+
+package directory
+
+import (
+	"errors"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+// ReadTransaction is the set of operations used by the directory layer to
+// read directories. fdb.Transaction and fdb.Snapshot satisfy ReadTransaction.
+type ReadTransaction interface {
+	Get(key fdb.KeyConvertible) fdb.FutureByteSlice
+	GetRange(r fdb.Range, options fdb.RangeOptions) fdb.RangeResult
+}
+
+// Transaction is the set of operations used by the directory layer to create,
+// move and remove directories. fdb.Transaction satisfies Transaction.
+//
+// As described in the package documentation, the directory layer performs its
+// reads and writes directly on a Transaction passed to it. A type that embeds
+// an fdb.Transaction and overrides some of its methods, for example to enforce
+// a quota on the keys written or to log every mutation, therefore sees every
+// operation performed by the directory layer.
+//
+// If a Transaction also has the Snapshot and Options methods of
+// fdb.Transaction, they are used to avoid unnecessary conflicts when
+// allocating directory prefixes. Otherwise, the allocator performs ordinary
+// reads and writes, which is correct but may cause more transactions to
+// conflict.
+type Transaction interface {
+	ReadTransaction
+	fdb.WriteTransaction
+}
+
+var (
+	_ ReadTransaction = fdb.Snapshot{}
+	_ Transaction     = fdb.Transaction{}
+)
+
+var errUsingTransaction = errors.New("a transaction passed to directory.Using or directory.UsingRead can only be used by the directory package")
+
+// Using returns an fdb.Transactor which causes the functions of this package
+// to perform their reads and writes on tr. It must only be passed to this
+// package: its Transact and ReadTransact methods return an error, since tr is
+// not an fdb.Transaction.
+func Using(tr Transaction) fdb.Transactor {
+	return using{tr}
+}
+
+type using struct {
+	Transaction
+}
+
+func (u using) Transact(func(fdb.Transaction) (interface{}, error)) (interface{}, error) {
+	return nil, errUsingTransaction
+}
+
+func (u using) ReadTransact(func(fdb.ReadTransaction) (interface{}, error)) (interface{}, error) {
+	return nil, errUsingTransaction
+}
+
+// UsingRead is like Using, but returns an fdb.ReadTransactor for the functions
+// of this package that only read directories.
+func UsingRead(rtr ReadTransaction) fdb.ReadTransactor {
+	return usingRead{rtr}
+}
+
+type usingRead struct {
+	ReadTransaction
+}
+
+func (u usingRead) ReadTransact(func(fdb.ReadTransaction) (interface{}, error)) (interface{}, error) {
+	return nil, errUsingTransaction
+}
+
+// transactor returns tr as an fdb.Transactor, for passing to the functions of
+// this package.
+func transactor(tr Transaction) fdb.Transactor {
+	if t, ok := tr.(fdb.Transactor); ok {
+		return t
+	}
+	return using{tr}
+}
+
+// readTransactor returns rtr as an fdb.ReadTransactor, for passing to the
+// functions of this package.
+func readTransactor(rtr ReadTransaction) fdb.ReadTransactor {
+	if rt, ok := rtr.(fdb.ReadTransactor); ok {
+		return rt
+	}
+	return usingRead{rtr}
+}
+
+// transact runs f in t, or in a transaction created by t if t is not itself a
+// Transaction.
+func transact(t fdb.Transactor, f func(Transaction) (interface{}, error)) (interface{}, error) {
+	if tr, ok := t.(Transaction); ok {
+		return call(f, tr)
+	}
+	return t.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		return f(tr)
+	})
+}
+
+// readTransact runs f in rt, or in a transaction created by rt if rt is not
+// itself a ReadTransaction.
+func readTransact(rt fdb.ReadTransactor, f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	if rtr, ok := rt.(ReadTransaction); ok {
+		return call(f, rtr)
+	}
+	return rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return f(rtr)
+	})
+}
+
+// call runs f, returning a panicked fdb.Error as an error, as the Transact
+// method of fdb.Transaction does.
+func call[T any](f func(T) (interface{}, error), tr T) (r interface{}, err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			fe, ok := rec.(fdb.Error)
+			if !ok {
+				panic(rec)
+			}
+			err = fe
+		}
+	}()
+	return f(tr)
+}
+
+// snapshot returns rtr's snapshot view, if it has one.
+func snapshot(rtr ReadTransaction) ReadTransaction {
+	if s, ok := rtr.(interface{ Snapshot() fdb.Snapshot }); ok {
+		return s.Snapshot()
+	}
+	return rtr
+}
+
+// setNextWriteNoWriteConflictRange prevents the next write to tr from adding a
+// write conflict range, if tr supports transaction options.
+func setNextWriteNoWriteConflictRange(tr Transaction) {
+	if o, ok := tr.(interface{ Options() fdb.TransactionOptions }); ok {
+		o.Options().SetNextWriteNoWriteConflictRange()
+	}
+}
@@ -180,6 +180,15 @@ Given these complexities, it is generally best practice to use a single
 goroutine for each logical thread of interaction with FoundationDB, and allow
 each goroutine to block when necessary to wait for Futures to become ready.
 
+# Contexts
+
+The TransactContext and ReadTransactContext methods of Database and Tenant
+accept a context.Context, allowing request deadlines and cancellation to bound
+a transactional function. Once the context is done, the retry loop stops, the
+transaction is cancelled (causing any outstanding futures to fail) and the
+context's error is returned. Every Future type also offers a GetContext method,
+which cancels the future if the context is done before the future is ready.
+
 # Streaming Modes
 
 When using GetRange methods in the FoundationDB API, clients can request large
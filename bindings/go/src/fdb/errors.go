@@ -46,6 +46,55 @@ func (e Error) Error() string {
 	return fmt.Sprintf("FoundationDB error code %d (%s)", e.Code, C.GoString(C.fdb_get_error(C.fdb_error_t(e.Code))))
 }
 
+// Name returns the name of the error as used in the FoundationDB source code
+// and documentation, such as "not_committed", or "unknown_error" if the code is
+// not a known error.
+func (e Error) Name() string {
+	if name, ok := errorNames[e.Code]; ok {
+		return name
+	}
+	return "unknown_error"
+}
+
+// Is reports whether target is an Error (or *Error) with the same code as e,
+// allowing errors.Is to match wrapped errors and pointers against the values
+// such as ErrNotCommitted.
+func (e Error) Is(target error) bool {
+	switch t := target.(type) {
+	case Error:
+		return e.Code == t.Code
+	case *Error:
+		return t != nil && e.Code == t.Code
+	}
+	return false
+}
+
+// IsRetryable reports whether the error is one that (Transaction).OnError
+// would retry: either the transaction was not committed, or it may have been
+// committed.
+func (e Error) IsRetryable() bool {
+	return errorPredicate(ErrorPredicateRetryable, e.Code)
+}
+
+// IsMaybeCommitted reports whether the transaction may have been committed
+// despite the error, as with commit_unknown_result. Retrying a transaction
+// that may have been committed applies its effects twice unless the
+// transaction is idempotent.
+func (e Error) IsMaybeCommitted() bool {
+	return errorPredicate(ErrorPredicateMaybeCommitted, e.Code)
+}
+
+// IsRetryableNotCommitted reports whether the error is retryable and the
+// transaction is known not to have been committed, so it is always safe to
+// retry.
+func (e Error) IsRetryableNotCommitted() bool {
+	return errorPredicate(ErrorPredicateRetryableNotCommitted, e.Code)
+}
+
+func errorPredicate(p ErrorPredicate, code int) bool {
+	return C.fdb_error_predicate(C.int(p), C.fdb_error_t(code)) != 0
+}
+
 // SOMEDAY: these (along with others) should be coming from fdb.options?
 
 var (
@@ -60,3 +60,52 @@ func TestErrorWrapping(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorIs(t *testing.T) {
+	wrapped := fmt.Errorf("wrapped error: %w", Error{Code: 1020})
+
+	if !errors.Is(wrapped, ErrNotCommitted) {
+		t.Errorf("expected %v to match ErrNotCommitted", wrapped)
+	}
+	if !errors.Is(wrapped, &Error{Code: 1020}) {
+		t.Errorf("expected %v to match a pointer to not_committed", wrapped)
+	}
+	if !errors.Is(&Error{Code: 1020}, ErrNotCommitted) {
+		t.Error("expected a pointer to not_committed to match ErrNotCommitted")
+	}
+	if errors.Is(wrapped, ErrTransactionTooOld) {
+		t.Errorf("expected %v not to match ErrTransactionTooOld", wrapped)
+	}
+
+	if name := ErrNotCommitted.Name(); name != "not_committed" {
+		t.Errorf("expected name not_committed, got %s", name)
+	}
+	if name := (Error{Code: 999999}).Name(); name != "unknown_error" {
+		t.Errorf("expected name unknown_error, got %s", name)
+	}
+}
+
+func TestErrorPredicates(t *testing.T) {
+	testCases := []struct {
+		err                                     Error
+		retryable, maybeCommitted, notCommitted bool
+	}{
+		{ErrNotCommitted, true, false, true},
+		{ErrTransactionTooOld, true, false, true},
+		{ErrCommitUnknownResult, true, true, false},
+		{ErrTransactionTimedOut, false, false, false},
+		{ErrKeyOutsideLegalRange, false, false, false},
+	}
+
+	for _, tc := range testCases {
+		if tc.err.IsRetryable() != tc.retryable {
+			t.Errorf("expected IsRetryable of %s to be %v", tc.err.Name(), tc.retryable)
+		}
+		if tc.err.IsMaybeCommitted() != tc.maybeCommitted {
+			t.Errorf("expected IsMaybeCommitted of %s to be %v", tc.err.Name(), tc.maybeCommitted)
+		}
+		if tc.err.IsRetryableNotCommitted() != tc.notCommitted {
+			t.Errorf("expected IsRetryableNotCommitted of %s to be %v", tc.err.Name(), tc.notCommitted)
+		}
+	}
+}
@@ -82,6 +82,48 @@ type ReadTransactor interface {
 	ReadTransact(func(ReadTransaction) (interface{}, error)) (interface{}, error)
 }
 
+// Transact is a typed variant of (Transactor).Transact. It executes the
+// caller-provided function with the same retry and commit semantics as the
+// Transact method of t, and returns the function's result as a T rather than
+// an interface{}, sparing the caller a type assertion.
+//
+// Transact may be called with a Database, Tenant or Transaction, allowing
+// composition of typed transactional functions.
+func Transact[T any](t Transactor, f func(Transaction) (T, error)) (T, error) {
+	ret, err := t.Transact(func(tr Transaction) (interface{}, error) {
+		return f(tr)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	// a nil interface{} (such as a nil T of interface type) does not satisfy
+	// the assertion and yields the zero value of T
+	v, _ := ret.(T)
+	return v, nil
+}
+
+// ReadTransact is a typed variant of (ReadTransactor).ReadTransact. It
+// executes the caller-provided function with the same retry semantics as the
+// ReadTransact method of rt, and returns the function's result as a T rather
+// than an interface{}.
+//
+// ReadTransact may be called with a Database, Tenant, Transaction or Snapshot,
+// allowing composition of typed read-only transactional functions.
+func ReadTransact[T any](rt ReadTransactor, f func(ReadTransaction) (T, error)) (T, error) {
+	ret, err := rt.ReadTransact(func(rtr ReadTransaction) (interface{}, error) {
+		return f(rtr)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	v, _ := ret.(T)
+	return v, nil
+}
+
 func setOpt(setter func(*C.uint8_t, C.int) C.fdb_error_t, param []byte) error {
 	if err := setter(byteSliceToPtr(param), C.int(len(param))); err != 0 {
 		return Error{int(err)}
@@ -203,6 +245,18 @@ func MustGetAPIVersion() int {
 	return apiVersion
 }
 
+// ClientVersion returns the version of the FoundationDB client library in use,
+// including its release version, source version and protocol version. When the
+// multi-version client API is enabled, this is the version of the primary
+// client library. ClientVersion returns an empty string if the API version has
+// not yet been set.
+func ClientVersion() string {
+	if !IsAPIVersionSelected() {
+		return ""
+	}
+	return C.GoString(C.fdb_get_client_version())
+}
+
 var apiVersion int
 var networkStarted, networkStopped bool
 var networkMutex sync.RWMutex
@@ -439,7 +493,7 @@ func OpenWithConnectionString(connectionString string) (Database, error) {
 
 	db := &database{outdb}
 
-	return Database{"", false, db}, nil
+	return Database{database: db}, nil
 }
 
 // Deprecated: Use OpenDatabase instead.
@@ -23,12 +23,19 @@
 package fdb_test
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/blob"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/directory"
 	"github.com/apple/foundationdb/bindings/go/src/fdb/subspace"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/tuple"
 )
 
 const API_VERSION int = 800
@@ -136,6 +143,659 @@ func TestReadTransactionOptions(t *testing.T) {
 	}
 }
 
+func TestTransactContextCancelled(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	called := false
+	_, err := db.TransactContext(ctx, func(tr fdb.Transaction) (interface{}, error) {
+		called = true
+		return nil, nil
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if called {
+		t.Error("transactional function was called with a cancelled context")
+	}
+}
+
+func TestReadTransactContextDeadline(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := db.ReadTransactContext(ctx, func(rtr fdb.ReadTransaction) (interface{}, error) {
+		// Keep retrying until the deadline is exceeded
+		return nil, fdb.Error{Code: 1020}
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	notCommitted := fdb.Error{Code: 1020}
+
+	testCases := []struct {
+		name     string
+		policy   fdb.RetryPolicy
+		attempts int
+	}{
+		{"max attempts", fdb.RetryPolicy{MaxAttempts: 3}, 3},
+		{"should retry", fdb.RetryPolicy{MaxAttempts: 3, ShouldRetry: func(fdb.Error) bool { return false }}, 1},
+		{"backoff", fdb.RetryPolicy{MaxAttempts: 2, Backoff: func(int) time.Duration { return time.Millisecond }}, 2},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			retries := 0
+			policy := tc.policy
+			policy.OnRetry = func(attempt int, err fdb.Error) {
+				retries++
+				if attempt != retries || err != notCommitted {
+					t.Errorf("unexpected retry %d after %v", attempt, err)
+				}
+			}
+
+			attempts := 0
+			_, err := db.WithRetryPolicy(&policy).Transact(func(tr fdb.Transaction) (interface{}, error) {
+				attempts++
+				return nil, notCommitted
+			})
+			if err != notCommitted {
+				t.Errorf("expected %v, got %v", notCommitted, err)
+			}
+			if attempts != tc.attempts || retries != tc.attempts-1 {
+				t.Errorf("expected %d attempts, got %d attempts and %d retries", tc.attempts, attempts, retries)
+			}
+		})
+	}
+}
+
+func TestInterceptors(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	var calls []string
+	var version int64
+	record := func(name string) fdb.Interceptor {
+		return fdb.Interceptor{
+			Transact: func(info fdb.TransactInfo, next func() error) error {
+				calls = append(calls, name+" transact")
+				return next()
+			},
+			Attempt: func(info fdb.AttemptInfo, next func() error) error {
+				calls = append(calls, fmt.Sprintf("%s attempt %d", name, info.Attempt))
+				return next()
+			},
+			Commit: func(info fdb.AttemptInfo, next func() error) error {
+				calls = append(calls, name+" commit")
+				err := next()
+				if err == nil {
+					version, err = info.Transaction.GetCommittedVersion()
+				}
+				return err
+			},
+		}
+	}
+
+	first := true
+	_, err := db.WithInterceptors(record("outer"), record("inner")).Transact(func(tr fdb.Transaction) (interface{}, error) {
+		if first {
+			first = false
+			return nil, fdb.Error{Code: 1020}
+		}
+		tr.Set(fdb.Key("fdb-go-interceptors"), []byte("value"))
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+
+	expected := []string{
+		"outer transact", "inner transact",
+		"outer attempt 1", "inner attempt 1",
+		"outer attempt 2", "inner attempt 2",
+		"outer commit", "inner commit",
+	}
+	if fmt.Sprint(calls) != fmt.Sprint(expected) {
+		t.Errorf("expected calls %v, got %v", expected, calls)
+	}
+	if version <= 0 {
+		t.Errorf("expected a committed version, got %d", version)
+	}
+}
+
+func TestTransactIdempotent(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	markers := fdb.Key("fdb-go-idempotency-markers/")
+	counter := fdb.Key("fdb-go-idempotent-counter")
+	one := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+
+	_, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(fdb.KeyRange{Begin: markers, End: append(markers, 0xFF)})
+		tr.Clear(counter)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to clear test keys: %v", err)
+	}
+
+	ret, err := fdb.TransactIdempotent(db, markers, func(tr fdb.Transaction) (interface{}, error) {
+		tr.Add(counter, one)
+		return "added", nil
+	})
+	if err != nil || ret != "added" {
+		t.Fatalf("expected added, got %v (%v)", ret, err)
+	}
+
+	cleared, err := fdb.CleanIdempotencyMarkers(db, markers, 0)
+	if err != nil {
+		t.Fatalf("failed to clean markers: %v", err)
+	}
+	if cleared != 1 {
+		t.Errorf("expected 1 marker to be cleared, got %d", cleared)
+	}
+
+	kvs, err := db.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return rtr.GetRange(fdb.KeyRange{Begin: markers, End: append(markers, 0xFF)}, fdb.RangeOptions{}).GetSliceWithError()
+	})
+	if err != nil {
+		t.Fatalf("failed to read markers: %v", err)
+	}
+	if n := len(kvs.([]fdb.KeyValue)); n != 0 {
+		t.Errorf("expected no markers after cleaning, got %d", n)
+	}
+}
+
+// unknownResult is a Transactor whose first successful attempt is committed
+// but reported as failing with commit_unknown_result, as if the connection to
+// the cluster had been lost during the commit.
+type unknownResult struct {
+	db       fdb.Database
+	injected bool
+}
+
+func (u *unknownResult) Transact(f func(fdb.Transaction) (interface{}, error)) (interface{}, error) {
+	return u.db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		ret, err := f(tr)
+		if err != nil || u.injected {
+			return ret, err
+		}
+		if err := tr.Commit().Get(); err != nil {
+			return nil, err
+		}
+		u.injected = true
+		return nil, fdb.ErrCommitUnknownResult
+	})
+}
+
+func (u *unknownResult) ReadTransact(f func(fdb.ReadTransaction) (interface{}, error)) (interface{}, error) {
+	return u.db.ReadTransact(f)
+}
+
+func TestTransactIdempotentUnknownResult(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	markers := fdb.Key("fdb-go-idempotency-markers/")
+	counter := fdb.Key("fdb-go-idempotent-counter")
+	one := []byte{1, 0, 0, 0, 0, 0, 0, 0}
+
+	_, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(fdb.KeyRange{Begin: markers, End: append(markers, 0xFF)})
+		tr.Clear(counter)
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to clear test keys: %v", err)
+	}
+
+	runs := 0
+	u := &unknownResult{db: db}
+	ret, err := fdb.TransactIdempotent(u, markers, func(tr fdb.Transaction) (interface{}, error) {
+		runs++
+		tr.Add(counter, one)
+		return runs, nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+	if !u.injected {
+		t.Fatal("expected commit_unknown_result to be injected")
+	}
+	if runs != 1 || ret != 1 {
+		t.Errorf("expected f to run once and its result to be returned, got %d runs and %v", runs, ret)
+	}
+
+	v, err := db.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return rtr.Get(counter).Get()
+	})
+	if err != nil {
+		t.Fatalf("failed to read counter: %v", err)
+	}
+	if !bytes.Equal(v.([]byte), one) {
+		t.Errorf("expected the counter to be added to once, got %x", v)
+	}
+
+	if _, err := fdb.CleanIdempotencyMarkers(db, markers, 0); err != nil {
+		t.Fatalf("failed to clean markers: %v", err)
+	}
+}
+
+func TestGetConflictingKeys(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	key := fdb.Key("fdb-go-conflicting-key")
+
+	tr1, err := db.CreateTransaction()
+	if err != nil {
+		t.Fatalf("failed to create transaction: %v", err)
+	}
+	if err := tr1.Options().SetReportConflictingKeys(); err != nil {
+		t.Fatalf("failed to set option: %v", err)
+	}
+	if _, err := tr1.Get(key).Get(); err != nil {
+		t.Fatalf("failed to read key: %v", err)
+	}
+
+	_, err = db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.Set(key, []byte("conflict"))
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+
+	tr1.Set(fdb.Key("fdb-go-conflicting-write"), []byte("value"))
+	if err := tr1.Commit().Get(); !errors.Is(err, fdb.ErrNotCommitted) {
+		t.Fatalf("expected not_committed, got %v", err)
+	}
+
+	ranges, err := tr1.GetConflictingKeys()
+	if err != nil {
+		t.Fatalf("failed to get conflicting keys: %v", err)
+	}
+	if len(ranges) != 1 || !bytes.Equal(ranges[0].Begin.FDBKey(), key) {
+		t.Errorf("expected a conflict on %s, got %v", key, ranges)
+	}
+}
+
+func TestTransactionCost(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	_, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		if err := tr.Options().SetTag("fdb-go-cost"); err != nil {
+			return nil, err
+		}
+		tr.Set(fdb.Key("fdb-go-cost"), []byte("value"))
+
+		cost, err := tr.GetTotalCost().Get()
+		if err != nil {
+			return nil, err
+		}
+		if cost < 0 {
+			t.Errorf("expected a non-negative cost, got %d", cost)
+		}
+
+		throttled, err := tr.GetTagThrottledDuration().Get()
+		if err != nil {
+			return nil, err
+		}
+		if throttled < 0 {
+			t.Errorf("expected a non-negative throttled duration, got %f", throttled)
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("transaction failed: %v", err)
+	}
+}
+
+func TestParallelScan(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	prefix := fdb.Key("fdb-go-scan/")
+	kr, err := fdb.PrefixRange(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const count = 2500
+	_, err = db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(kr)
+		for i := 0; i < count; i++ {
+			tr.Set(append(prefix, []byte(fmt.Sprintf("%05d", i))...), []byte("value"))
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to write keys: %v", err)
+	}
+
+	var keys []fdb.Key
+	options := fdb.ParallelScanOptions{Concurrency: 4, ChunkSize: 4096, BatchSize: 100, Ordered: true}
+	err = fdb.ParallelScan(context.Background(), db, kr, options, func(kvs []fdb.KeyValue) error {
+		for _, kv := range kvs {
+			keys = append(keys, kv.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("parallel scan failed: %v", err)
+	}
+
+	if len(keys) != count {
+		t.Fatalf("expected %d keys, got %d", count, len(keys))
+	}
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) >= 0 {
+			t.Fatalf("keys out of order at %d: %s, %s", i, keys[i-1], keys[i])
+		}
+	}
+
+	stop := errors.New("stop")
+	err = fdb.ParallelScan(context.Background(), db, kr, fdb.ParallelScanOptions{}, func(kvs []fdb.KeyValue) error {
+		return stop
+	})
+	if err != stop {
+		t.Fatalf("expected the callback error, got %v", err)
+	}
+}
+
+func TestRangePage(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	prefix := fdb.Key("fdb-go-page/")
+	kr, err := fdb.PrefixRange(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const count = 250
+	_, err = db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(kr)
+		for i := 0; i < count; i++ {
+			tr.Set(append(prefix, []byte(fmt.Sprintf("%05d", i))...), []byte("value"))
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatalf("failed to write keys: %v", err)
+	}
+
+	// readPages reads the range 30 key-value pairs at a time, in a new
+	// transaction for each page, passing the token through its text form
+	readPages := func(options fdb.RangeOptions) []fdb.Key {
+		var keys []fdb.Key
+		var r fdb.Range = kr
+		for pages := 0; ; pages++ {
+			if pages > count {
+				t.Fatal("too many pages")
+			}
+			// The transaction may be retried, so the page is only appended to
+			// keys once it has been read
+			var page []fdb.KeyValue
+			var token fdb.RangeToken
+			_, err := db.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+				var err error
+				page, token, err = rtr.GetRange(r, options).Page(30)
+				return nil, err
+			})
+			if err != nil {
+				t.Fatalf("failed to read page: %v", err)
+			}
+			for _, kv := range page {
+				keys = append(keys, kv.Key)
+			}
+			if token == nil {
+				return keys
+			}
+
+			text, err := token.MarshalText()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var parsed fdb.RangeToken
+			if err := parsed.UnmarshalText(text); err != nil {
+				t.Fatalf("failed to parse token %s: %v", text, err)
+			}
+			r, options, err = fdb.ResumeRange(parsed, kr)
+			if err != nil {
+				t.Fatalf("failed to resume range: %v", err)
+			}
+		}
+	}
+
+	keys := readPages(fdb.RangeOptions{})
+	if len(keys) != count {
+		t.Fatalf("expected %d keys, got %d", count, len(keys))
+	}
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) >= 0 {
+			t.Fatalf("keys out of order at %d: %s, %s", i, keys[i-1], keys[i])
+		}
+	}
+
+	keys = readPages(fdb.RangeOptions{Limit: 100, Reverse: true})
+	if len(keys) != 100 {
+		t.Fatalf("expected 100 keys, got %d", len(keys))
+	}
+	if last := append(prefix, []byte(fmt.Sprintf("%05d", count-1))...); !bytes.Equal(keys[0], last) {
+		t.Errorf("expected a reverse read to begin at %s, got %s", last, keys[0])
+	}
+	for i := 1; i < len(keys); i++ {
+		if bytes.Compare(keys[i-1], keys[i]) <= 0 {
+			t.Fatalf("keys out of order at %d: %s, %s", i, keys[i-1], keys[i])
+		}
+	}
+}
+
+func TestResumeRangeInvalid(t *testing.T) {
+	for _, text := range []string{"", "AA", "not a token", "AQA", "AQIA"} {
+		var token fdb.RangeToken
+		if err := token.UnmarshalText([]byte(text)); err != fdb.ErrInvalidRangeToken {
+			t.Errorf("expected %q to be an invalid token, got %v", text, err)
+		}
+	}
+}
+
+func TestBulkWriter(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	prefix := fdb.Key("fdb-go-bulk/")
+	kr, err := fdb.PrefixRange(prefix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(kr)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("failed to clear keys: %v", err)
+	}
+
+	const count = 5000
+	var calls int
+	var w *fdb.BulkWriter
+	w = fdb.NewBulkWriter(context.Background(), db, fdb.BulkWriterOptions{
+		MaxBatchBytes: 4096,
+		Concurrency:   4,
+		OnProgress: func(p fdb.BulkProgress) {
+			calls++
+			// The callback may read the progress of the writer
+			if w.Progress().KeysWritten < p.KeysWritten {
+				t.Errorf("expected Progress to include the reported progress")
+			}
+		},
+	})
+	var last fdb.Key
+	for i := 0; i < count; i++ {
+		last = append(prefix[:len(prefix):len(prefix)], []byte(fmt.Sprintf("%05d", i))...)
+		if err := w.Write(fdb.KeyValue{Key: last, Value: []byte("value")}); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("close failed: %v", err)
+	}
+
+	progress := w.Progress()
+	if progress.KeysWritten != count {
+		t.Errorf("expected %d keys written, got %d", count, progress.KeysWritten)
+	}
+	if !bytes.Equal(progress.Checkpoint, last) {
+		t.Errorf("expected checkpoint %s, got %s", last, progress.Checkpoint)
+	}
+	if calls < 2 {
+		t.Errorf("expected the writes to be split across transactions, got %d", calls)
+	}
+
+	kvs, err := db.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return rtr.GetRange(kr, fdb.RangeOptions{}).GetSliceWithError()
+	})
+	if err != nil {
+		t.Fatalf("failed to read keys: %v", err)
+	}
+	if n := len(kvs.([]fdb.KeyValue)); n != count {
+		t.Errorf("expected %d keys, got %d", count, n)
+	}
+
+	if err := w.Write(fdb.KeyValue{Key: last}); err == nil {
+		t.Error("expected a write after close to fail")
+	}
+
+	// A partial batch is committed once it has been buffered for
+	// MaxBatchDuration, without waiting for further writes
+	stale := fdb.NewBulkWriter(context.Background(), db, fdb.BulkWriterOptions{MaxBatchDuration: 10 * time.Millisecond})
+	defer stale.Close()
+	if err := stale.Write(fdb.KeyValue{Key: last, Value: []byte("value")}); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for stale.Progress().KeysWritten == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("expected a partial batch to be committed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBlobStore(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	sub := subspace.Sub("fdb-go-blob")
+	if _, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.ClearRange(sub)
+		return nil, nil
+	}); err != nil {
+		t.Fatalf("failed to clear blobs: %v", err)
+	}
+
+	store, err := blob.New(db, sub, blob.Options{ChunkSize: 1000, TransactionBytes: 10000, PrefetchChunks: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	data := bytes.Repeat([]byte("0123456789abcdefghijklmnopqrstuvwxyz"), 2000)
+	if err := store.Put(ctx, "object", bytes.NewReader(data)); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	r, err := store.Open(ctx, "object")
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	if r.Size() != int64(len(data)) {
+		t.Errorf("expected size %d, got %d", len(data), r.Size())
+	}
+	var got bytes.Buffer
+	if _, err := got.ReadFrom(r); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	if !bytes.Equal(got.Bytes(), data) {
+		t.Error("read data does not match written data")
+	}
+
+	part := make([]byte, 5000)
+	if _, err := r.ReadAt(part, 12345); err != nil {
+		t.Fatalf("read at failed: %v", err)
+	}
+	if !bytes.Equal(part, data[12345:17345]) {
+		t.Error("data read at offset does not match written data")
+	}
+
+	w, err := store.Create(ctx, "abandoned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(data); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+	if removed, err := store.CollectGarbage(ctx, 0); err != nil || removed != 1 {
+		t.Fatalf("expected one upload to be collected, got %d, %v", removed, err)
+	}
+	if err := w.Close(); !errors.Is(err, blob.ErrAbandoned) {
+		t.Errorf("expected closing a collected upload to fail, got %v", err)
+	}
+
+	if err := store.Delete(ctx, "object"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := store.Open(ctx, "object"); !errors.Is(err, blob.ErrNotFound) {
+		t.Errorf("expected a deleted object to be missing, got %v", err)
+	}
+}
+
+// auditTransaction records the keys set in a transaction.
+type auditTransaction struct {
+	fdb.Transaction
+	written *[]fdb.Key
+}
+
+func (a auditTransaction) Set(key fdb.KeyConvertible, value []byte) {
+	*a.written = append(*a.written, key.FDBKey())
+	a.Transaction.Set(key, value)
+}
+
+func TestDirectoryWrappedTransaction(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	path := []string{"fdb-go-wrapped"}
+	var written []fdb.Key
+	_, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		if _, err := directory.Root().Remove(tr, path); err != nil {
+			return nil, err
+		}
+		return directory.Create(auditTransaction{tr, &written}, path, nil)
+	})
+	if err != nil {
+		t.Fatalf("failed to create directory: %v", err)
+	}
+	if len(written) == 0 {
+		t.Error("expected the directory layer to write through the wrapped transaction")
+	}
+}
+
 func ExampleTransactor() {
 	fdb.MustAPIVersion(API_VERSION)
 	db := fdb.MustOpenDefault()
@@ -334,6 +994,72 @@ func TestKeyToString(t *testing.T) {
 	// Output:
 }
 
+func ExampleRangeResult_All() {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	tr, err := db.CreateTransaction()
+	if err != nil {
+		fmt.Printf("Unable to create transaction: %v\n", err)
+		return
+	}
+
+	// Clear and initialize data in this transaction. In examples we do not
+	// commit transactions to avoid mutating a real database.
+	tr.ClearRange(fdb.KeyRange{fdb.Key(""), fdb.Key{0xFF}})
+	tr.Set(fdb.Key("apple"), []byte("foo"))
+	tr.Set(fdb.Key("cherry"), []byte("baz"))
+	tr.Set(fdb.Key("banana"), []byte("bar"))
+
+	for kv, err := range tr.GetRange(fdb.KeyRange{fdb.Key(""), fdb.Key{0xFF}}, fdb.RangeOptions{}).All() {
+		if err != nil {
+			fmt.Printf("Unable to read next value: %v\n", err)
+			return
+		}
+		fmt.Printf("%s is %s\n", kv.Key, kv.Value)
+
+		// Breaking out of the loop cancels any batch still being fetched.
+		if string(kv.Key) == "banana" {
+			break
+		}
+	}
+
+	// Output:
+	// apple is foo
+	// banana is bar
+}
+
+func ExampleTransaction_GetMappedRange() {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	index := subspace.Sub("index")
+
+	// Index entries have keys of the form ("index", color, id), and refer to
+	// records stored under ("record", id, field).
+	mapper := tuple.Tuple{"record", "{K[2]}", "{...}"}
+
+	_, err := db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		kr, err := fdb.PrefixRange(index.Pack(tuple.Tuple{"blue"}))
+		if err != nil {
+			return nil, err
+		}
+
+		ri := tr.GetMappedRange(kr, mapper, fdb.RangeOptions{}).Iterator()
+		for ri.Advance() {
+			mkv, err := ri.Get()
+			if err != nil {
+				return nil, err
+			}
+			fmt.Printf("%s: %d fields\n", mkv.Key, len(mkv.RangeResult))
+		}
+		return nil, nil
+	})
+	if err != nil {
+		fmt.Printf("Unable to perform mapped range read: %v\n", err)
+	}
+}
+
 func ExamplePrintable() {
 	fmt.Println(fdb.Printable([]byte{0, 1, 2, 'a', 'b', 'c', '1', '2', '3', '!', '?', 255}))
 	// Output: \x00\x01\x02abc123!?\xff
@@ -406,6 +1132,81 @@ func TestGetClientStatus(t *testing.T) {
 	}
 }
 
+func TestParseClientStatus(t *testing.T) {
+	report := []byte(`{
+		"Healthy": true,
+		"InitializationState": "created",
+		"ProtocolVersion": "fdb00b073000000",
+		"ConnectionRecord": "fdb.cluster",
+		"AvailableClients": [
+			{"ProtocolVersion": "fdb00b073000000", "ReleaseVersion": "7.3.0", "ThreadIndex": 0}
+		],
+		"DatabaseStatus": {
+			"Healthy": true,
+			"Coordinators": ["127.0.0.1:4500"],
+			"CurrentCoordinator": "127.0.0.1:4500",
+			"GrvProxies": ["127.0.0.1:4500"],
+			"CommitProxies": ["127.0.0.1:4500"],
+			"Connections": [
+				{"Address": "127.0.0.1:4500", "Status": "connected", "Compatible": true, "ProtocolVersion": "fdb00b073000000"}
+			],
+			"NumConnectionsFailed": 0
+		}
+	}`)
+
+	st, err := fdb.ParseClientStatus(report)
+	if err != nil {
+		t.Fatalf("ParseClientStatus failed: %v", err)
+	}
+	if !st.Healthy || st.InitializationState != "created" {
+		t.Errorf("unexpected state: healthy %t, initialization state %q", st.Healthy, st.InitializationState)
+	}
+	if st.ProtocolVersion != 0x0FDB00B073000000 {
+		t.Errorf("unexpected protocol version %s", st.ProtocolVersion)
+	}
+	if len(st.AvailableClients) != 1 || st.AvailableClients[0].ReleaseVersion != "7.3.0" {
+		t.Errorf("unexpected available clients %+v", st.AvailableClients)
+	}
+	if st.DatabaseStatus == nil || len(st.DatabaseStatus.Connections) != 1 || st.DatabaseStatus.Connections[0].Status != "connected" {
+		t.Errorf("unexpected database status %+v", st.DatabaseStatus)
+	}
+
+	if _, err := fdb.ParseClientStatus([]byte(`{"ProtocolVersion": "not hex"}`)); err == nil {
+		t.Error("expected an invalid protocol version to fail to parse")
+	}
+}
+
+func TestDatabaseDiagnostics(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	if v := fdb.ClientVersion(); v == "" {
+		t.Error("expected a client version")
+	}
+
+	protocol, err := db.GetServerProtocol(0).Get()
+	if err != nil {
+		t.Fatalf("GetServerProtocol failed: %v", err)
+	}
+	if protocol == 0 {
+		t.Error("expected a server protocol version")
+	}
+
+	if busyness := db.MainThreadBusyness(); busyness < 0 || busyness > 1 {
+		t.Errorf("expected main thread busyness between 0 and 1, got %f", busyness)
+	}
+}
+
+func TestCreateSnapshotInvalidUID(t *testing.T) {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	err := db.CreateSnapshot("not-a-uid", "/bin/snapshot").Get()
+	if !errors.Is(err, fdb.ErrSnapInvalidUidString) {
+		t.Fatalf("expected snap_invalid_uid_string, got %v", err)
+	}
+}
+
 func ExampleDatabase_GetClientStatus() {
 	fdb.MustAPIVersion(API_VERSION)
 	err := fdb.Options().SetDisableClientBypass()
@@ -431,3 +1232,77 @@ func ExampleDatabase_GetClientStatus() {
 
 	// Output:
 }
+
+func ExampleDatabase_OpenTenant() {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	// The tenant must already exist in the cluster for transactions to succeed.
+	tenant, err := db.OpenTenant(fdb.Key("customer-42"))
+	if err != nil {
+		fmt.Printf("Unable to open tenant: %v\n", err)
+		return
+	}
+
+	// Keys written within a tenant are isolated from all other tenants.
+	_, err = tenant.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.Set(fdb.Key("hello"), []byte("world"))
+		return nil, nil
+	})
+	if err != nil {
+		fmt.Printf("Unable to write to tenant: %v\n", err)
+		return
+	}
+
+	id, err := tenant.GetID().Get()
+	if err != nil {
+		fmt.Printf("Unable to get tenant ID: %v\n", err)
+		return
+	}
+	fmt.Printf("tenant ID: %d\n", id)
+}
+
+func ExampleDatabase_WatchKey() {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The first event carries the current value; later events are delivered
+	// each time the value changes, until the context is done.
+	for ev := range db.WatchKey(ctx, fdb.Key("config")) {
+		if ev.Err != nil {
+			fmt.Printf("Unable to watch key: %v\n", ev.Err)
+			return
+		}
+		fmt.Printf("config is %q at version %d\n", ev.Value, ev.Version)
+	}
+}
+
+func ExampleTransact() {
+	fdb.MustAPIVersion(API_VERSION)
+	db := fdb.MustOpenDefault()
+
+	// The result of the transactional function is returned as a []byte, no
+	// type assertion required.
+	value, err := fdb.Transact(db, func(tr fdb.Transaction) ([]byte, error) {
+		tr.Set(fdb.Key("hello"), []byte("world"))
+		return tr.Get(fdb.Key("hello")).Get()
+	})
+	if err != nil {
+		fmt.Printf("Transaction failed: %v\n", err)
+		return
+	}
+	fmt.Printf("hello is %s\n", value)
+
+	// Typed futures may be waited on together.
+	values, err := fdb.ReadTransact(db, func(rtr fdb.ReadTransaction) ([][]byte, error) {
+		return fdb.GetAll[[]byte](rtr.Get(fdb.Key("hello")), rtr.Get(fdb.Key("foo")))
+	})
+	if err != nil {
+		fmt.Printf("Transaction failed: %v\n", err)
+		return
+	}
+	fmt.Printf("read %d values\n", len(values))
+}
@@ -0,0 +1,271 @@
+/*
+ * fdbotel.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go OpenTelemetry Integration
+
+// Package fdbotel connects FoundationDB transactions to OpenTelemetry tracing.
+//
+// The Interceptor function returns an fdb.Interceptor which creates a client
+// span for each call to Transact or ReadTransact, and for each attempt and
+// commit within it, as children of the span in the caller's context. Each
+// attempt sets the span parent of its transaction, so that spans recorded by
+// the FoundationDB client and cluster continue the caller's trace. Reads may be
+// traced individually with Get and GetRange, using the context returned by
+// Context.
+//
+// fdbotel is a separate Go module, so that the fdb package itself remains free
+// of dependencies outside of the Go standard library.
+package fdbotel
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"sync"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/apple/foundationdb/bindings/go/src/fdb/fdbotel"
+
+// spanParentProtocolVersion is the protocol version prefixed to serialized
+// span contexts. Any protocol version understood by the client may be used.
+const spanParentProtocolVersion = 0x0FDB00B073000000
+
+var dbSystem = attribute.String("db.system", "foundationdb")
+
+// spans maps each transaction being run by a traced Transact call to the
+// context of its innermost span: the span of the current attempt while one is
+// running, and the span of the Transact call otherwise.
+var spans sync.Map
+
+type config struct {
+	tracerProvider       trace.TracerProvider
+	serverRequestTracing bool
+}
+
+// Option configures the spans created by an Interceptor.
+type Option func(*config)
+
+// WithTracerProvider sets the tracer provider used to create spans. By default
+// the global tracer provider is used.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = tp
+	}
+}
+
+// WithServerRequestTracing sets the server request tracing option on each
+// transaction attempt whose span is sampled, so that the cluster logs the
+// progress of its commit.
+func WithServerRequestTracing() Option {
+	return func(c *config) {
+		c.serverRequestTracing = true
+	}
+}
+
+// EnableNetworkTracer configures the FoundationDB client to send the spans it
+// records to a collector over the network, so that they can be joined with
+// the spans created by this package. The cluster should be configured with the
+// same tracer. Like other network options, it must be set before the network
+// is started.
+func EnableNetworkTracer() error {
+	return fdb.Options().SetDistributedClientTracer("network_lossy")
+}
+
+// SpanParent returns the serialized form of sc expected by
+// (fdb.TransactionOptions).SetSpanParent, or nil if sc is not valid.
+func SpanParent(sc trace.SpanContext) []byte {
+	if !sc.IsValid() {
+		return nil
+	}
+
+	traceID := sc.TraceID()
+	spanID := sc.SpanID()
+
+	b := make([]byte, 33)
+	binary.LittleEndian.PutUint64(b[0:], spanParentProtocolVersion)
+	binary.LittleEndian.PutUint64(b[8:], binary.BigEndian.Uint64(traceID[0:8]))
+	binary.LittleEndian.PutUint64(b[16:], binary.BigEndian.Uint64(traceID[8:16]))
+	binary.LittleEndian.PutUint64(b[24:], binary.BigEndian.Uint64(spanID[:]))
+	if sc.IsSampled() {
+		b[32] = 1
+	}
+	return b
+}
+
+// SetSpanParent sets the span in ctx as the parent of the span of the
+// transaction. It does nothing if ctx does not contain a valid span.
+func SetSpanParent(ctx context.Context, tr fdb.Transaction) error {
+	sp := SpanParent(trace.SpanContextFromContext(ctx))
+	if sp == nil {
+		return nil
+	}
+	return tr.Options().SetSpanParent(sp)
+}
+
+// Interceptor returns an fdb.Interceptor that traces the transactions run by a
+// database:
+//
+//	db = db.WithInterceptors(fdbotel.Interceptor())
+//
+// Spans are created as children of the span in the context passed to
+// TransactContext or ReadTransactContext. Errors returned by each attempt are
+// recorded as events on the enclosing span.
+func Interceptor(opts ...Option) fdb.Interceptor {
+	c := config{tracerProvider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	tracer := c.tracerProvider.Tracer(instrumentationName)
+
+	return fdb.Interceptor{
+		Transact: func(info fdb.TransactInfo, next func() error) error {
+			name := "fdb.Transact"
+			if info.ReadOnly {
+				name = "fdb.ReadTransact"
+			}
+			ctx, span := tracer.Start(info.Context, name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(dbSystem))
+			defer span.End()
+
+			spans.Store(info.Transaction, ctx)
+			defer spans.Delete(info.Transaction)
+
+			err := next()
+			end(span, err)
+			return err
+		},
+
+		Attempt: func(info fdb.AttemptInfo, next func() error) error {
+			tr := info.Transaction
+			parent := contextOr(tr, info.Context)
+			ctx, span := tracer.Start(parent, "fdb.Attempt",
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(dbSystem, attribute.Int("fdb.attempt", info.Attempt)))
+			defer span.End()
+
+			if err := SetSpanParent(ctx, tr); err != nil {
+				return err
+			}
+			if c.serverRequestTracing && span.SpanContext().IsSampled() {
+				if err := tr.Options().SetServerRequestTracing(); err != nil {
+					return err
+				}
+			}
+
+			spans.Store(tr, ctx)
+			defer spans.Store(tr, parent)
+
+			err := next()
+			var ep fdb.Error
+			if errors.As(err, &ep) {
+				trace.SpanFromContext(parent).AddEvent("fdb.error", trace.WithAttributes(
+					attribute.Int("fdb.attempt", info.Attempt),
+					attribute.Int("fdb.error.code", ep.Code)))
+			}
+			end(span, err)
+			return err
+		},
+
+		Commit: func(info fdb.AttemptInfo, next func() error) error {
+			ctx := contextOr(info.Transaction, info.Context)
+			_, span := tracer.Start(ctx, "fdb.Commit",
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(dbSystem))
+			defer span.End()
+
+			if size, err := info.Transaction.GetApproximateSize().Get(); err == nil {
+				span.SetAttributes(attribute.Int64("fdb.approximate_size", size))
+			}
+
+			err := next()
+			if err == nil {
+				if version, err := info.Transaction.GetCommittedVersion(); err == nil {
+					span.SetAttributes(attribute.Int64("fdb.committed_version", version))
+				}
+			}
+			end(span, err)
+			return err
+		},
+	}
+}
+
+// Context returns the context of the span of the attempt currently being run
+// by tr, for use with Get and GetRange or to start spans of other operations
+// within a transactional function. It returns context.Background() if tr is not
+// being run by a Transact call traced by an Interceptor.
+func Context(tr fdb.Transaction) context.Context {
+	return contextOr(tr, context.Background())
+}
+
+func contextOr(tr fdb.Transaction, fallback context.Context) context.Context {
+	if ctx, ok := spans.Load(tr); ok {
+		return ctx.(context.Context)
+	}
+	return fallback
+}
+
+// Get reads the value of key in a span which is a child of the span in ctx.
+func Get(ctx context.Context, rt fdb.ReadTransaction, key fdb.KeyConvertible) ([]byte, error) {
+	_, span := start(ctx, "fdb.Get")
+	defer span.End()
+
+	v, err := rt.Get(key).Get()
+	end(span, err)
+	return v, err
+}
+
+// GetRange reads the key-value pairs in r in a span which is a child of the
+// span in ctx.
+func GetRange(ctx context.Context, rt fdb.ReadTransaction, r fdb.Range, options fdb.RangeOptions) ([]fdb.KeyValue, error) {
+	_, span := start(ctx, "fdb.GetRange")
+	defer span.End()
+
+	kvs, err := rt.GetRange(r, options).GetSliceWithError()
+	if err == nil {
+		span.SetAttributes(attribute.Int("fdb.rows", len(kvs)))
+	}
+	end(span, err)
+	return kvs, err
+}
+
+// start creates a span using the tracer provider of the span in ctx.
+func start(ctx context.Context, name string) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(instrumentationName)
+	return tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(dbSystem))
+}
+
+func end(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	var ep fdb.Error
+	if errors.As(err, &ep) {
+		span.SetAttributes(attribute.Int("fdb.error.code", ep.Code))
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,32 @@
+package fdbotel
+
+import (
+	"bytes"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestSpanParent(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18},
+		SpanID:     trace.SpanID{0x21, 0x22, 0x23, 0x24, 0x25, 0x26, 0x27, 0x28},
+		TraceFlags: trace.FlagsSampled,
+	})
+
+	expected := []byte{
+		0x00, 0x00, 0x00, 0x73, 0xB0, 0x00, 0xDB, 0x0F, // protocol version
+		0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01, // trace id (first)
+		0x18, 0x17, 0x16, 0x15, 0x14, 0x13, 0x12, 0x11, // trace id (second)
+		0x28, 0x27, 0x26, 0x25, 0x24, 0x23, 0x22, 0x21, // span id
+		0x01, // sampled
+	}
+
+	if sp := SpanParent(sc); !bytes.Equal(sp, expected) {
+		t.Errorf("expected span parent %x, got %x", expected, sp)
+	}
+
+	if sp := SpanParent(trace.SpanContext{}); sp != nil {
+		t.Errorf("expected no span parent for an invalid span context, got %x", sp)
+	}
+}
@@ -0,0 +1,19 @@
+module github.com/apple/foundationdb/bindings/go/src/fdb/fdbotel
+
+go 1.23.0
+
+require (
+	github.com/apple/foundationdb/bindings/go v0.0.0-00010101000000-000000000000
+	go.opentelemetry.io/otel v1.38.0
+	go.opentelemetry.io/otel/trace v1.38.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	go.opentelemetry.io/auto/sdk v1.1.0 // indirect
+	go.opentelemetry.io/otel/metric v1.38.0 // indirect
+)
+
+// fdbotel is developed alongside the bindings in this repository.
+replace github.com/apple/foundationdb/bindings/go => ../../..
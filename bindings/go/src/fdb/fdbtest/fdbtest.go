@@ -0,0 +1,138 @@
+/*
+ * fdbtest.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Test Support
+
+// Package fdbtest provides an in-memory database for unit testing code that
+// uses FoundationDB without running a cluster.
+//
+// Code to be tested is written against the Transactor, ReadTransactor,
+// Transaction and ReadTransaction interfaces of this package rather than the
+// concrete types of the fdb package. In production, an fdb.Database or
+// fdb.Tenant is adapted to these interfaces with Wrap; in tests, a
+// MemoryDatabase is used instead:
+//
+//	func addUser(t fdbtest.Transactor, users subspace.Subspace, name string) error {
+//		_, err := t.Transact(func(tr fdbtest.Transaction) (interface{}, error) {
+//			tr.Set(users.Pack(tuple.Tuple{name}), nil)
+//			return nil, nil
+//		})
+//		return err
+//	}
+//
+//	addUser(fdbtest.Wrap(db), users, "alice")            // with a cluster
+//	addUser(fdbtest.NewMemoryDatabase(), users, "alice") // in a unit test
+//
+// A MemoryDatabase implements the semantics of FoundationDB transactions that
+// are observable by a single process: each transaction reads a consistent
+// snapshot of the database at its read version and sees its own writes, and a
+// transaction whose reads conflict with the writes of a transaction committed
+// after its read version fails to commit with not_committed (error 1020), after
+// which Transact retries it. Atomic operations, versionstamped keys and values,
+// key selectors, snapshot reads and conflict ranges are supported, as are the
+// limits on the size of keys and values. Durability, the system key space, the
+// limits on transaction size and duration, and database and transaction
+// options are not modeled.
+package fdbtest
+
+import (
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+// ReadTransaction is the set of read operations shared by fdb.Transaction,
+// fdb.Snapshot and *MemoryTransaction.
+type ReadTransaction interface {
+	Get(key fdb.KeyConvertible) fdb.FutureByteSlice
+	GetKey(sel fdb.Selectable) fdb.FutureKey
+	GetRange(r fdb.Range, options fdb.RangeOptions) fdb.RangeResult
+	GetReadVersion() fdb.FutureInt64
+}
+
+// Transaction is the set of operations shared by fdb.Transaction and
+// *MemoryTransaction. The transactions passed by Wrap implement Transaction
+// with an fdb.Transaction, whose Snapshot method returns an fdb.Snapshot.
+//
+// A Transaction may be passed to the functions of the directory package with
+// directory.Using, and a ReadTransaction with directory.UsingRead.
+type Transaction interface {
+	ReadTransaction
+	fdb.WriteTransaction
+
+	// Snapshot returns a view of the transaction whose reads do not cause
+	// the transaction to conflict with writes to the keys read.
+	Snapshot() ReadTransaction
+
+	SetReadVersion(version int64)
+	GetVersionstamp() fdb.FutureKey
+	GetCommittedVersion() (int64, error)
+}
+
+// ReadTransactor can execute a function that requires a ReadTransaction, in
+// the same way as fdb.ReadTransactor.
+type ReadTransactor interface {
+	ReadTransact(func(ReadTransaction) (interface{}, error)) (interface{}, error)
+}
+
+// Transactor can execute a function that requires a Transaction, with the
+// same retry and commit semantics as fdb.Transactor.
+type Transactor interface {
+	Transact(func(Transaction) (interface{}, error)) (interface{}, error)
+
+	ReadTransactor
+}
+
+var (
+	_ Transaction     = transaction{}
+	_ ReadTransaction = fdb.Snapshot{}
+	_ ReadTransaction = fdb.ReadTransaction(nil)
+	_ Transaction     = (*MemoryTransaction)(nil)
+	_ Transactor      = (*MemoryDatabase)(nil)
+)
+
+// Wrap adapts an fdb.Transactor, such as an fdb.Database or fdb.Tenant, to
+// the Transactor interface of this package.
+func Wrap(t fdb.Transactor) Transactor {
+	return transactor{t}
+}
+
+type transactor struct {
+	t fdb.Transactor
+}
+
+func (t transactor) Transact(f func(Transaction) (interface{}, error)) (interface{}, error) {
+	return t.t.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		return f(transaction{tr})
+	})
+}
+
+func (t transactor) ReadTransact(f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	return t.t.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return f(rtr)
+	})
+}
+
+// transaction adapts an fdb.Transaction to the Transaction interface.
+type transaction struct {
+	fdb.Transaction
+}
+
+func (t transaction) Snapshot() ReadTransaction {
+	return t.Transaction.Snapshot()
+}
@@ -0,0 +1,152 @@
+/*
+ * future.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Test Support
+
+package fdbtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+// future is an in-memory implementation of the Future types of the fdb
+// package that produce a value.
+type future[T any] struct {
+	ready chan struct{}
+	once  sync.Once
+	value T
+	err   error
+}
+
+var (
+	_ fdb.FutureByteSlice = (*future[[]byte])(nil)
+	_ fdb.FutureKey       = (*future[fdb.Key])(nil)
+	_ fdb.FutureInt64     = (*future[int64])(nil)
+	_ fdb.FutureNil       = nilFuture{}
+)
+
+func newFuture[T any]() *future[T] {
+	return &future[T]{ready: make(chan struct{})}
+}
+
+func readyFuture[T any](value T, err error) *future[T] {
+	f := newFuture[T]()
+	f.set(value, err)
+	return f
+}
+
+// set makes the future ready. Only the first call has any effect.
+func (f *future[T]) set(value T, err error) {
+	f.once.Do(func() {
+		f.value, f.err = value, err
+		close(f.ready)
+	})
+}
+
+func (f *future[T]) Get() (T, error) {
+	<-f.ready
+	return f.value, f.err
+}
+
+func (f *future[T]) GetContext(ctx context.Context) (T, error) {
+	select {
+	case <-f.ready:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func (f *future[T]) MustGet() T {
+	value, err := f.Get()
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+func (f *future[T]) BlockUntilReady() {
+	<-f.ready
+}
+
+func (f *future[T]) IsReady() bool {
+	select {
+	case <-f.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f *future[T]) Cancel() {
+	var zero T
+	f.set(zero, fdb.ErrOperationCancelled)
+}
+
+// nilFuture is an in-memory implementation of fdb.FutureNil.
+type nilFuture struct {
+	f *future[struct{}]
+}
+
+func newNilFuture() nilFuture {
+	return nilFuture{newFuture[struct{}]()}
+}
+
+func readyNilFuture(err error) nilFuture {
+	f := newNilFuture()
+	f.set(err)
+	return f
+}
+
+func (f nilFuture) set(err error) {
+	f.f.set(struct{}{}, err)
+}
+
+func (f nilFuture) Get() error {
+	_, err := f.f.Get()
+	return err
+}
+
+func (f nilFuture) GetContext(ctx context.Context) error {
+	_, err := f.f.GetContext(ctx)
+	return err
+}
+
+func (f nilFuture) MustGet() {
+	if err := f.Get(); err != nil {
+		panic(err)
+	}
+}
+
+func (f nilFuture) BlockUntilReady() {
+	f.f.BlockUntilReady()
+}
+
+func (f nilFuture) IsReady() bool {
+	return f.f.IsReady()
+}
+
+func (f nilFuture) Cancel() {
+	f.f.Cancel()
+}
@@ -0,0 +1,983 @@
+/*
+ * memory.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Test Support
+
+package fdbtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+const (
+	maxKeySize   = 10000
+	maxValueSize = 100000
+)
+
+// The first key of the system key space, and the end of the key space
+// visible to a MemoryTransaction.
+var systemKeys = []byte{0xff}
+
+// keyRange is a half-open range of keys.
+type keyRange struct {
+	begin, end []byte
+}
+
+func singleKeyRange(key []byte) keyRange {
+	return keyRange{key, keyAfter(key)}
+}
+
+func (r keyRange) intersects(o keyRange) bool {
+	return bytes.Compare(r.begin, o.end) < 0 && bytes.Compare(o.begin, r.end) < 0
+}
+
+func keyAfter(key []byte) []byte {
+	return append(key[:len(key):len(key)], 0x00)
+}
+
+// versionedValue is the value of a key as of a version. A key that has been
+// cleared has a versionedValue that is not present.
+type versionedValue struct {
+	version int64
+	value   []byte
+	present bool
+}
+
+type commitRecord struct {
+	version int64
+	writes  []keyRange
+}
+
+// watch is a watch on a key, which fires once the value of the key differs
+// from its value when the watch was registered.
+type watch struct {
+	key     []byte
+	value   []byte
+	present bool
+	f       nilFuture
+}
+
+// MemoryDatabase is an in-memory database that implements the Transactor
+// interface. The zero value is not usable; a MemoryDatabase is created with
+// NewMemoryDatabase. A MemoryDatabase is safe for concurrent use by multiple
+// goroutines.
+//
+// Every committed version of every key is kept in memory for the lifetime of
+// the MemoryDatabase, so it is intended for tests rather than large data sets.
+type MemoryDatabase struct {
+	mu      sync.Mutex
+	version int64
+	keys    [][]byte
+	history map[string][]versionedValue
+	commits []commitRecord
+	watches []*watch
+}
+
+// NewMemoryDatabase returns an empty MemoryDatabase.
+func NewMemoryDatabase() *MemoryDatabase {
+	return &MemoryDatabase{history: make(map[string][]versionedValue)}
+}
+
+// CreateTransaction returns a new MemoryTransaction whose read version is the
+// latest committed version of d.
+func (d *MemoryDatabase) CreateTransaction() *MemoryTransaction {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return &MemoryTransaction{
+		db:               d,
+		readVersion:      d.version,
+		versionstamp:     newFuture[fdb.Key](),
+		committedVersion: -1,
+	}
+}
+
+// Transact runs f with a new MemoryTransaction and commits it, retrying f
+// with a new transaction whenever f or the commit fails with a retryable
+// error, such as a conflict. As with (fdb.Database).Transact, an fdb.Error
+// panicked by f is recovered and handled as if it had been returned.
+func (d *MemoryDatabase) Transact(f func(Transaction) (interface{}, error)) (interface{}, error) {
+	for {
+		tr := d.CreateTransaction()
+		ret, err := call(f, Transaction(tr))
+		if err == nil {
+			err = tr.commit()
+			if err == nil {
+				return ret, nil
+			}
+		}
+		tr.Cancel()
+
+		if !retryable(err) {
+			return nil, err
+		}
+	}
+}
+
+// ReadTransact runs f with a new MemoryTransaction, retrying f with a new
+// transaction whenever it fails with a retryable error. The transaction is not
+// committed.
+func (d *MemoryDatabase) ReadTransact(f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	for {
+		tr := d.CreateTransaction()
+		ret, err := call(f, ReadTransaction(tr))
+		tr.Cancel()
+
+		if err == nil || !retryable(err) {
+			return ret, err
+		}
+	}
+}
+
+func call[T any](f func(T) (interface{}, error), tr T) (ret interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			fe, ok := r.(fdb.Error)
+			if !ok {
+				panic(r)
+			}
+			err = fe
+		}
+	}()
+	return f(tr)
+}
+
+func retryable(err error) bool {
+	var ep fdb.Error
+	if !errors.As(err, &ep) {
+		return false
+	}
+	return ep == fdb.ErrNotCommitted || ep == fdb.ErrTransactionTooOld || ep == fdb.ErrCommitUnknownResult
+}
+
+// valueAt returns the value of key as of version. d.mu must be held.
+func (d *MemoryDatabase) valueAt(key []byte, version int64) ([]byte, bool) {
+	h := d.history[string(key)]
+	for i := len(h) - 1; i >= 0; i-- {
+		if h[i].version <= version {
+			return h[i].value, h[i].present
+		}
+	}
+	return nil, false
+}
+
+// keysIn returns every key in [begin, end) that has ever had a value. d.mu
+// must be held.
+func (d *MemoryDatabase) keysIn(begin, end []byte) [][]byte {
+	i := sort.Search(len(d.keys), func(i int) bool { return bytes.Compare(d.keys[i], begin) >= 0 })
+	j := sort.Search(len(d.keys), func(i int) bool { return bytes.Compare(d.keys[i], end) >= 0 })
+	if i >= j {
+		return nil
+	}
+	return slices.Clone(d.keys[i:j])
+}
+
+// put sets the value of key as of version, which must be at least the
+// latest version of key. d.mu must be held.
+func (d *MemoryDatabase) put(key, value []byte, present bool, version int64) {
+	h, ok := d.history[string(key)]
+	if !ok {
+		i := sort.Search(len(d.keys), func(i int) bool { return bytes.Compare(d.keys[i], key) >= 0 })
+		d.keys = slices.Insert(d.keys, i, key)
+	}
+
+	v := versionedValue{version: version, value: value, present: present}
+	if len(h) > 0 && h[len(h)-1].version == version {
+		h[len(h)-1] = v
+	} else {
+		h = append(h, v)
+	}
+	d.history[string(key)] = h
+}
+
+// addWatches registers watches, recording the latest values of their keys.
+// d.mu must be held.
+func (d *MemoryDatabase) addWatches(watches []*watch) {
+	for _, w := range watches {
+		w.value, w.present = d.valueAt(w.key, d.version)
+		d.watches = append(d.watches, w)
+	}
+}
+
+// fireWatches fires the watches whose keys have changed. d.mu must be held.
+func (d *MemoryDatabase) fireWatches() {
+	d.watches = slices.DeleteFunc(d.watches, func(w *watch) bool {
+		if w.f.IsReady() {
+			// The watch was cancelled
+			return true
+		}
+		value, present := d.valueAt(w.key, d.version)
+		if present == w.present && bytes.Equal(value, w.value) {
+			return false
+		}
+		w.f.set(nil)
+		return true
+	})
+}
+
+type mutationType int
+
+const (
+	mutationSet mutationType = iota
+	mutationClear
+	mutationClearRange
+	mutationAtomic
+	mutationVersionstampedKey
+	mutationVersionstampedValue
+)
+
+// atomicOp computes the new value of a key from its existing value and the
+// parameter of an atomic operation.
+type atomicOp func(value []byte, present bool, param []byte) ([]byte, bool)
+
+type mutation struct {
+	typ   mutationType
+	key   []byte
+	end   []byte
+	param []byte
+	op    atomicOp
+}
+
+// MemoryTransaction is a transaction on a MemoryDatabase. It reads the
+// database as of its read version, sees its own writes, and is checked for
+// conflicts with the transactions committed after its read version when it is
+// committed. A MemoryTransaction is safe for concurrent use by multiple
+// goroutines.
+//
+// Versionstamped keys and values are assigned when the transaction commits. As
+// in FoundationDB, reading a key whose value was set by SetVersionstampedValue
+// in the same transaction fails with accessed_unreadable, and keys set by
+// SetVersionstampedKey are not visible to the transaction's own reads.
+type MemoryTransaction struct {
+	db *MemoryDatabase
+
+	mu               sync.Mutex
+	readVersion      int64
+	ops              []mutation
+	reads            []keyRange
+	writes           []keyRange
+	err              error
+	watches          []*watch
+	versionstamp     *future[fdb.Key]
+	committed        bool
+	committedVersion int64
+}
+
+// get returns the value of key as seen by the transaction. t.mu must be held.
+func (t *MemoryTransaction) get(key []byte) ([]byte, bool, error) {
+	t.db.mu.Lock()
+	value, present := t.db.valueAt(key, t.readVersion)
+	t.db.mu.Unlock()
+
+	unreadable := false
+	for _, m := range t.ops {
+		switch m.typ {
+		case mutationSet:
+			if bytes.Equal(m.key, key) {
+				value, present, unreadable = m.param, true, false
+			}
+		case mutationClear:
+			if bytes.Equal(m.key, key) {
+				value, present, unreadable = nil, false, false
+			}
+		case mutationClearRange:
+			if (keyRange{m.key, m.end}).intersects(singleKeyRange(key)) {
+				value, present, unreadable = nil, false, false
+			}
+		case mutationAtomic:
+			if bytes.Equal(m.key, key) {
+				value, present = m.op(value, present, m.param)
+			}
+		case mutationVersionstampedValue:
+			if bytes.Equal(m.key, key) {
+				unreadable = true
+			}
+		}
+	}
+
+	if unreadable {
+		return nil, false, fdb.ErrAccessedUnreadable
+	}
+	return value, present, nil
+}
+
+// view returns the key-value pairs in [begin, end) as seen by the
+// transaction, in key order. t.mu must be held.
+func (t *MemoryTransaction) view(begin, end []byte) ([]fdb.KeyValue, error) {
+	r := keyRange{begin, end}
+
+	t.db.mu.Lock()
+	candidates := t.db.keysIn(begin, end)
+	t.db.mu.Unlock()
+	for _, m := range t.ops {
+		switch m.typ {
+		case mutationSet, mutationAtomic, mutationVersionstampedValue:
+			if r.intersects(singleKeyRange(m.key)) {
+				candidates = append(candidates, m.key)
+			}
+		}
+	}
+	slices.SortFunc(candidates, bytes.Compare)
+	candidates = slices.CompactFunc(candidates, bytes.Equal)
+
+	var kvs []fdb.KeyValue
+	for _, key := range candidates {
+		value, present, err := t.get(key)
+		if err != nil {
+			return nil, err
+		}
+		if present {
+			kvs = append(kvs, fdb.KeyValue{Key: slices.Clone(key), Value: slices.Clone(value)})
+		}
+	}
+	return kvs, nil
+}
+
+// resolve returns the key selected by sel, limited to the key space before
+// the system keys. t.mu must be held.
+func (t *MemoryTransaction) resolve(sel fdb.KeySelector) ([]byte, error) {
+	kvs, err := t.view(nil, systemKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	key := sel.Key.FDBKey()
+	before := sort.Search(len(kvs), func(i int) bool {
+		c := bytes.Compare(kvs[i].Key, key)
+		return c > 0 || (c == 0 && !sel.OrEqual)
+	})
+
+	i := before - 1 + sel.Offset
+	if i < 0 {
+		return []byte{}, nil
+	}
+	if i >= len(kvs) {
+		return slices.Clone(systemKeys), nil
+	}
+	return kvs[i].Key, nil
+}
+
+// Get returns the value of key as seen by the transaction, or nil if the key
+// is not present.
+func (t *MemoryTransaction) Get(key fdb.KeyConvertible) fdb.FutureByteSlice {
+	return t.readValue(key, false)
+}
+
+// GetKey returns the key selected by sel.
+func (t *MemoryTransaction) GetKey(sel fdb.Selectable) fdb.FutureKey {
+	return t.readKey(sel, false)
+}
+
+// GetRange returns the key-value pairs in r as seen by the transaction. The
+// whole range is read when GetRange is called, so the streaming mode of
+// options has no effect.
+func (t *MemoryTransaction) GetRange(r fdb.Range, options fdb.RangeOptions) fdb.RangeResult {
+	return t.readRange(r, options, false)
+}
+
+// Snapshot returns a view of the transaction whose reads, as with
+// (fdb.Transaction).Snapshot, do not cause the transaction to conflict with
+// writes to the keys read. Snapshot reads see the transaction's own writes.
+func (t *MemoryTransaction) Snapshot() ReadTransaction {
+	return memorySnapshot{t}
+}
+
+// readValue implements Get, recording a read conflict unless snapshot is set.
+func (t *MemoryTransaction) readValue(key fdb.KeyConvertible, snapshot bool) fdb.FutureByteSlice {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	k := key.FDBKey()
+	value, present, err := t.get(k)
+	if err != nil {
+		return readyFuture[[]byte](nil, err)
+	}
+	if !snapshot {
+		t.reads = append(t.reads, singleKeyRange(k))
+	}
+	if !present {
+		return readyFuture[[]byte](nil, nil)
+	}
+	return readyFuture(slices.Clone(value), nil)
+}
+
+// readKey implements GetKey, recording a read conflict unless snapshot is
+// set.
+func (t *MemoryTransaction) readKey(sel fdb.Selectable, snapshot bool) fdb.FutureKey {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ks := sel.FDBKeySelector()
+	key, err := t.resolve(ks)
+	if err != nil {
+		return readyFuture[fdb.Key](nil, err)
+	}
+
+	lo, hi := ks.Key.FDBKey(), key
+	if bytes.Compare(lo, hi) > 0 {
+		lo, hi = hi, lo
+	}
+	if !snapshot {
+		t.reads = append(t.reads, keyRange{lo, keyAfter(hi)})
+	}
+	return readyFuture(fdb.Key(key), nil)
+}
+
+// readRange implements GetRange, recording a read conflict unless snapshot is
+// set.
+func (t *MemoryTransaction) readRange(r fdb.Range, options fdb.RangeOptions, snapshot bool) fdb.RangeResult {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bsel, esel := r.FDBRangeKeySelectors()
+	begin, err := t.resolve(bsel.FDBKeySelector())
+	if err != nil {
+		return fdb.NewRangeResult(r, options, nil, err)
+	}
+	end, err := t.resolve(esel.FDBKeySelector())
+	if err != nil {
+		return fdb.NewRangeResult(r, options, nil, err)
+	}
+	if bytes.Compare(begin, end) >= 0 {
+		return fdb.NewRangeResult(r, options, nil, nil)
+	}
+
+	kvs, err := t.view(begin, end)
+	if err != nil {
+		return fdb.NewRangeResult(r, options, nil, err)
+	}
+	if options.Reverse {
+		slices.Reverse(kvs)
+	}
+
+	read := keyRange{begin, end}
+	if options.Limit > 0 && len(kvs) >= options.Limit {
+		kvs = kvs[:options.Limit]
+		// Only the part of the range that was returned is read
+		last := kvs[len(kvs)-1].Key
+		if options.Reverse {
+			read.begin = last
+		} else {
+			read.end = keyAfter(last)
+		}
+	}
+	if !snapshot {
+		t.reads = append(t.reads, read)
+	}
+
+	return fdb.NewRangeResult(r, options, kvs, nil)
+}
+
+// GetReadVersion returns the version at which the transaction reads the
+// database.
+func (t *MemoryTransaction) GetReadVersion() fdb.FutureInt64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return readyFuture(t.readVersion, nil)
+}
+
+// memorySnapshot is the snapshot view of a MemoryTransaction.
+type memorySnapshot struct {
+	t *MemoryTransaction
+}
+
+func (s memorySnapshot) Get(key fdb.KeyConvertible) fdb.FutureByteSlice {
+	return s.t.readValue(key, true)
+}
+
+func (s memorySnapshot) GetKey(sel fdb.Selectable) fdb.FutureKey {
+	return s.t.readKey(sel, true)
+}
+
+func (s memorySnapshot) GetRange(r fdb.Range, options fdb.RangeOptions) fdb.RangeResult {
+	return s.t.readRange(r, options, true)
+}
+
+func (s memorySnapshot) GetReadVersion() fdb.FutureInt64 {
+	return s.t.GetReadVersion()
+}
+
+// SetReadVersion sets the version at which the transaction reads the
+// database.
+func (t *MemoryTransaction) SetReadVersion(version int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.readVersion = version
+}
+
+// fail records an error to be returned when the transaction is committed.
+// t.mu must be held.
+func (t *MemoryTransaction) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// checkKey reports whether key may be written by the transaction, recording
+// an error otherwise. t.mu must be held.
+func (t *MemoryTransaction) checkKey(key []byte) bool {
+	switch {
+	case len(key) > maxKeySize:
+		t.fail(fdb.ErrKeyTooLarge)
+	case bytes.Compare(key, systemKeys) >= 0:
+		t.fail(fdb.ErrKeyOutsideLegalRange)
+	default:
+		return true
+	}
+	return false
+}
+
+func (t *MemoryTransaction) mutate(m mutation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	m.key = slices.Clone(m.key)
+	m.param = slices.Clone(m.param)
+	if !t.checkKey(m.key) {
+		return
+	}
+	if m.typ == mutationSet && len(m.param) > maxValueSize {
+		t.fail(fdb.ErrValueTooLarge)
+		return
+	}
+
+	t.ops = append(t.ops, m)
+	if m.typ != mutationVersionstampedKey {
+		// The key of a versionstamped key is only known once it is committed
+		t.writes = append(t.writes, singleKeyRange(m.key))
+	}
+}
+
+// Set sets the value of key.
+func (t *MemoryTransaction) Set(key fdb.KeyConvertible, value []byte) {
+	t.mutate(mutation{typ: mutationSet, key: key.FDBKey(), param: value})
+}
+
+// Clear removes key.
+func (t *MemoryTransaction) Clear(key fdb.KeyConvertible) {
+	t.mutate(mutation{typ: mutationClear, key: key.FDBKey()})
+}
+
+// ClearRange removes every key in er.
+func (t *MemoryTransaction) ClearRange(er fdb.ExactRange) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bk, ek := er.FDBRangeKeys()
+	begin, end := slices.Clone(bk.FDBKey()), slices.Clone(ek.FDBKey())
+	if bytes.Compare(begin, end) > 0 {
+		t.fail(fdb.ErrInvertedRange)
+		return
+	}
+	if bytes.Compare(end, systemKeys) > 0 {
+		t.fail(fdb.ErrKeyOutsideLegalRange)
+		return
+	}
+
+	t.ops = append(t.ops, mutation{typ: mutationClearRange, key: begin, end: end})
+	t.writes = append(t.writes, keyRange{begin, end})
+}
+
+func (t *MemoryTransaction) atomic(key fdb.KeyConvertible, param []byte, op atomicOp) {
+	t.mutate(mutation{typ: mutationAtomic, key: key.FDBKey(), param: param, op: op})
+}
+
+// Add adds param to the value of key, both interpreted as little-endian
+// unsigned integers of the length of param.
+func (t *MemoryTransaction) Add(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opAdd)
+}
+
+// BitAnd sets the value of key to the bitwise and of its value and param.
+func (t *MemoryTransaction) BitAnd(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opBitAnd)
+}
+
+// BitOr sets the value of key to the bitwise or of its value and param.
+func (t *MemoryTransaction) BitOr(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opBitOr)
+}
+
+// BitXor sets the value of key to the bitwise xor of its value and param.
+func (t *MemoryTransaction) BitXor(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opBitXor)
+}
+
+// Max sets the value of key to the larger of its value and param, both
+// interpreted as little-endian unsigned integers.
+func (t *MemoryTransaction) Max(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opMax)
+}
+
+// Min sets the value of key to the smaller of its value and param, both
+// interpreted as little-endian unsigned integers.
+func (t *MemoryTransaction) Min(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opMin)
+}
+
+// ByteMax sets the value of key to the lexicographically larger of its value
+// and param.
+func (t *MemoryTransaction) ByteMax(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opByteMax)
+}
+
+// ByteMin sets the value of key to the lexicographically smaller of its value
+// and param.
+func (t *MemoryTransaction) ByteMin(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opByteMin)
+}
+
+// CompareAndClear removes key if its value is equal to param.
+func (t *MemoryTransaction) CompareAndClear(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opCompareAndClear)
+}
+
+// AppendIfFits appends param to the value of key, unless the result would be
+// larger than the maximum value size.
+func (t *MemoryTransaction) AppendIfFits(key fdb.KeyConvertible, param []byte) {
+	t.atomic(key, param, opAppendIfFits)
+}
+
+// SetVersionstampedKey sets the value of key to param, once the versionstamp
+// of the transaction has been written into key at the offset given by its last
+// four bytes.
+func (t *MemoryTransaction) SetVersionstampedKey(key fdb.KeyConvertible, param []byte) {
+	k := key.FDBKey()
+	if !validVersionstampPlaceholder(k) {
+		t.mu.Lock()
+		t.fail(fdb.ErrClientInvalidOperation)
+		t.mu.Unlock()
+		return
+	}
+	t.mutate(mutation{typ: mutationVersionstampedKey, key: k, param: param})
+}
+
+// SetVersionstampedValue sets the value of key to param, once the
+// versionstamp of the transaction has been written into param at the offset
+// given by its last four bytes.
+func (t *MemoryTransaction) SetVersionstampedValue(key fdb.KeyConvertible, param []byte) {
+	if !validVersionstampPlaceholder(param) {
+		t.mu.Lock()
+		t.fail(fdb.ErrClientInvalidOperation)
+		t.mu.Unlock()
+		return
+	}
+	t.mutate(mutation{typ: mutationVersionstampedValue, key: key.FDBKey(), param: param})
+}
+
+// AddReadConflictRange adds er to the ranges checked for conflicts when the
+// transaction is committed.
+func (t *MemoryTransaction) AddReadConflictRange(er fdb.ExactRange) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bk, ek := er.FDBRangeKeys()
+	t.reads = append(t.reads, keyRange{slices.Clone(bk.FDBKey()), slices.Clone(ek.FDBKey())})
+	return nil
+}
+
+// AddReadConflictKey adds key to the keys checked for conflicts when the
+// transaction is committed.
+func (t *MemoryTransaction) AddReadConflictKey(key fdb.KeyConvertible) error {
+	return t.AddReadConflictRange(fdb.KeyRange{Begin: key, End: fdb.Key(keyAfter(key.FDBKey()))})
+}
+
+// AddWriteConflictRange adds er to the ranges that conflict with other
+// transactions once the transaction is committed.
+func (t *MemoryTransaction) AddWriteConflictRange(er fdb.ExactRange) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bk, ek := er.FDBRangeKeys()
+	t.writes = append(t.writes, keyRange{slices.Clone(bk.FDBKey()), slices.Clone(ek.FDBKey())})
+	return nil
+}
+
+// AddWriteConflictKey adds key to the keys that conflict with other
+// transactions once the transaction is committed.
+func (t *MemoryTransaction) AddWriteConflictKey(key fdb.KeyConvertible) error {
+	return t.AddWriteConflictRange(fdb.KeyRange{Begin: key, End: fdb.Key(keyAfter(key.FDBKey()))})
+}
+
+// GetVersionstamp returns a future for the versionstamp of the transaction,
+// which becomes ready when the transaction is committed.
+func (t *MemoryTransaction) GetVersionstamp() fdb.FutureKey {
+	return t.versionstamp
+}
+
+// GetCommittedVersion returns the version at which the transaction was
+// committed, or -1 if it has not been committed or was read-only.
+func (t *MemoryTransaction) GetCommittedVersion() (int64, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.committedVersion, nil
+}
+
+// Watch returns a future that becomes ready once the value of key changes
+// from its value when the transaction is committed. The watch is registered
+// when the transaction is committed; if the transaction fails to commit or is
+// cancelled, the future fails with the same error.
+func (t *MemoryTransaction) Watch(key fdb.KeyConvertible) fdb.FutureNil {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	w := &watch{key: slices.Clone(key.FDBKey()), f: newNilFuture()}
+	t.watches = append(t.watches, w)
+	return w.f
+}
+
+// Commit commits the transaction. Once a transaction has been committed,
+// further calls to Commit have no effect.
+func (t *MemoryTransaction) Commit() fdb.FutureNil {
+	return readyNilFuture(t.commit())
+}
+
+// Cancel cancels the transaction if it has not been committed, causing its
+// versionstamp, its watches and any later commit to fail.
+func (t *MemoryTransaction) Cancel() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.committed {
+		t.fail(fdb.ErrTransactionCancelled)
+		t.abort(fdb.ErrTransactionCancelled)
+	}
+}
+
+// abort fails the versionstamp and watches of the transaction with err. t.mu
+// must be held.
+func (t *MemoryTransaction) abort(err error) {
+	t.versionstamp.set(nil, err)
+	for _, w := range t.watches {
+		w.f.set(err)
+	}
+	t.watches = nil
+}
+
+// commit applies the writes of the transaction to the database, unless a
+// transaction committed after its read version wrote to a range it read.
+func (t *MemoryTransaction) commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.committed {
+		return nil
+	}
+	if t.err != nil {
+		t.abort(t.err)
+		return t.err
+	}
+
+	d := t.db
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if len(t.ops) == 0 && len(t.writes) == 0 {
+		t.committed = true
+		t.versionstamp.set(nil, fdb.ErrNoCommitVersion)
+		d.addWatches(t.watches)
+		t.watches = nil
+		return nil
+	}
+
+	for _, c := range d.commits {
+		if c.version <= t.readVersion {
+			continue
+		}
+		for _, w := range c.writes {
+			for _, r := range t.reads {
+				if w.intersects(r) {
+					t.abort(fdb.ErrNotCommitted)
+					return fdb.ErrNotCommitted
+				}
+			}
+		}
+	}
+
+	d.version++
+	version := d.version
+	stamp := make([]byte, 10)
+	binary.BigEndian.PutUint64(stamp, uint64(version))
+
+	writes := slices.Clone(t.writes)
+	for _, m := range t.ops {
+		switch m.typ {
+		case mutationSet:
+			d.put(m.key, m.param, true, version)
+		case mutationClear:
+			d.put(m.key, nil, false, version)
+		case mutationClearRange:
+			for _, key := range d.keysIn(m.key, m.end) {
+				if _, present := d.valueAt(key, version); present {
+					d.put(key, nil, false, version)
+				}
+			}
+		case mutationAtomic:
+			value, present := d.valueAt(m.key, version)
+			value, present = m.op(value, present, m.param)
+			d.put(m.key, value, present, version)
+		case mutationVersionstampedKey:
+			key := fillVersionstamp(m.key, stamp)
+			d.put(key, m.param, true, version)
+			writes = append(writes, singleKeyRange(key))
+		case mutationVersionstampedValue:
+			d.put(m.key, fillVersionstamp(m.param, stamp), true, version)
+		}
+	}
+	d.commits = append(d.commits, commitRecord{version: version, writes: writes})
+	d.fireWatches()
+	d.addWatches(t.watches)
+
+	t.committed = true
+	t.committedVersion = version
+	t.watches = nil
+	t.versionstamp.set(stamp, nil)
+	return nil
+}
+
+// validVersionstampPlaceholder reports whether b ends with the little-endian
+// offset of a 10-byte placeholder for a versionstamp within the rest of b.
+func validVersionstampPlaceholder(b []byte) bool {
+	if len(b) < 4 {
+		return false
+	}
+	offset := int(binary.LittleEndian.Uint32(b[len(b)-4:]))
+	return offset+10 <= len(b)-4
+}
+
+// fillVersionstamp returns b without its trailing offset, with stamp written
+// at the offset.
+func fillVersionstamp(b, stamp []byte) []byte {
+	offset := binary.LittleEndian.Uint32(b[len(b)-4:])
+	out := slices.Clone(b[:len(b)-4])
+	copy(out[offset:], stamp)
+	return out
+}
+
+// resize returns value truncated or zero-extended to n bytes.
+func resize(value []byte, n int) []byte {
+	out := make([]byte, n)
+	copy(out, value)
+	return out
+}
+
+// compareLittleEndian compares a and b, which have the same length, as
+// little-endian unsigned integers.
+func compareLittleEndian(a, b []byte) int {
+	for i := len(a) - 1; i >= 0; i-- {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func opAdd(value []byte, present bool, param []byte) ([]byte, bool) {
+	out := resize(value, len(param))
+	carry := 0
+	for i := range out {
+		sum := int(out[i]) + int(param[i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return out, true
+}
+
+func bitwise(f func(a, b byte) byte) atomicOp {
+	return func(value []byte, present bool, param []byte) ([]byte, bool) {
+		out := resize(value, len(param))
+		for i := range out {
+			out[i] = f(out[i], param[i])
+		}
+		return out, true
+	}
+}
+
+var (
+	opBitOr  = bitwise(func(a, b byte) byte { return a | b })
+	opBitXor = bitwise(func(a, b byte) byte { return a ^ b })
+)
+
+func opBitAnd(value []byte, present bool, param []byte) ([]byte, bool) {
+	if !present {
+		return param, true
+	}
+	return bitwise(func(a, b byte) byte { return a & b })(value, present, param)
+}
+
+func opMax(value []byte, present bool, param []byte) ([]byte, bool) {
+	value = resize(value, len(param))
+	if present && compareLittleEndian(value, param) > 0 {
+		return value, true
+	}
+	return param, true
+}
+
+func opMin(value []byte, present bool, param []byte) ([]byte, bool) {
+	value = resize(value, len(param))
+	if present && compareLittleEndian(value, param) < 0 {
+		return value, true
+	}
+	return param, true
+}
+
+func opByteMax(value []byte, present bool, param []byte) ([]byte, bool) {
+	if present && bytes.Compare(value, param) > 0 {
+		return value, true
+	}
+	return param, true
+}
+
+func opByteMin(value []byte, present bool, param []byte) ([]byte, bool) {
+	if present && bytes.Compare(value, param) < 0 {
+		return value, true
+	}
+	return param, true
+}
+
+func opCompareAndClear(value []byte, present bool, param []byte) ([]byte, bool) {
+	if present && bytes.Equal(value, param) {
+		return nil, false
+	}
+	return value, present
+}
+
+func opAppendIfFits(value []byte, present bool, param []byte) ([]byte, bool) {
+	if !present {
+		return param, true
+	}
+	if len(value)+len(param) > maxValueSize {
+		return value, true
+	}
+	return append(value[:len(value):len(value)], param...), true
+}
@@ -0,0 +1,504 @@
+package fdbtest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/directory"
+	"github.com/apple/foundationdb/bindings/go/src/fdb/subspace"
+)
+
+func mustCommit(t *testing.T, tr *MemoryTransaction) {
+	t.Helper()
+	if err := tr.commit(); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+}
+
+func keys(kvs []fdb.KeyValue) []string {
+	var ret []string
+	for _, kv := range kvs {
+		ret = append(ret, string(kv.Key))
+	}
+	return ret
+}
+
+func TestReadYourWrites(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	tr.Set(fdb.Key("a"), []byte("1"))
+	tr.Set(fdb.Key("b"), []byte("2"))
+	tr.Set(fdb.Key("c"), []byte("3"))
+	mustCommit(t, tr)
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("d"), []byte("4"))
+	tr.ClearRange(fdb.KeyRange{Begin: fdb.Key("a"), End: fdb.Key("c")})
+	tr.Set(fdb.Key("b"), []byte("5"))
+
+	if v := tr.Get(fdb.Key("a")).MustGet(); v != nil {
+		t.Errorf("expected cleared key to be missing, got %q", v)
+	}
+	if v := tr.Get(fdb.Key("b")).MustGet(); string(v) != "5" {
+		t.Errorf("expected the transaction's own write, got %q", v)
+	}
+
+	kvs := tr.GetRange(fdb.KeyRange{Begin: fdb.Key(""), End: fdb.Key("\xff")}, fdb.RangeOptions{}).GetSliceOrPanic()
+	if got := keys(kvs); !slices.Equal(got, []string{"b", "c", "d"}) {
+		t.Errorf("unexpected range read %v", got)
+	}
+}
+
+func TestSnapshotIsolation(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	tr.Set(fdb.Key("k"), []byte("old"))
+	mustCommit(t, tr)
+
+	reader := db.CreateTransaction()
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("k"), []byte("new"))
+	tr.Set(fdb.Key("other"), []byte("x"))
+	mustCommit(t, tr)
+
+	if v := reader.Get(fdb.Key("k")).MustGet(); string(v) != "old" {
+		t.Errorf("expected the value as of the read version, got %q", v)
+	}
+	if v := reader.Get(fdb.Key("other")).MustGet(); v != nil {
+		t.Errorf("expected a key written after the read version to be missing, got %q", v)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	t1 := db.CreateTransaction()
+	t2 := db.CreateTransaction()
+
+	t1.Get(fdb.Key("counter")).MustGet()
+	t1.Set(fdb.Key("counter"), []byte("1"))
+
+	t2.Get(fdb.Key("counter")).MustGet()
+	t2.Set(fdb.Key("counter"), []byte("2"))
+
+	mustCommit(t, t1)
+	if err := t2.commit(); err != fdb.ErrNotCommitted {
+		t.Fatalf("expected not_committed, got %v", err)
+	}
+	if _, err := t2.GetVersionstamp().Get(); err != fdb.ErrNotCommitted {
+		t.Errorf("expected the versionstamp of a failed commit to fail, got %v", err)
+	}
+
+	// Blind writes do not conflict
+	t3 := db.CreateTransaction()
+	t4 := db.CreateTransaction()
+	t3.Set(fdb.Key("counter"), []byte("3"))
+	t4.Set(fdb.Key("counter"), []byte("4"))
+	mustCommit(t, t3)
+	mustCommit(t, t4)
+
+	// Reads limited by a range limit only conflict with the part returned
+	t5 := db.CreateTransaction()
+	t5.GetRange(fdb.KeyRange{Begin: fdb.Key(""), End: fdb.Key("\xff")}, fdb.RangeOptions{Limit: 1}).GetSliceOrPanic()
+	t5.Set(fdb.Key("x"), nil)
+	t6 := db.CreateTransaction()
+	t6.Set(fdb.Key("z"), nil)
+	mustCommit(t, t6)
+	mustCommit(t, t5)
+
+	// Snapshot reads do not conflict
+	t7 := db.CreateTransaction()
+	t7.Snapshot().Get(fdb.Key("counter")).MustGet()
+	t7.Snapshot().GetRange(fdb.KeyRange{Begin: fdb.Key(""), End: fdb.Key("\xff")}, fdb.RangeOptions{}).GetSliceOrPanic()
+	t7.Set(fdb.Key("counter"), []byte("7"))
+	t8 := db.CreateTransaction()
+	t8.Set(fdb.Key("counter"), []byte("8"))
+	mustCommit(t, t8)
+	mustCommit(t, t7)
+}
+
+func TestTransactRetries(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	attempts := 0
+	_, err := db.Transact(func(tr Transaction) (interface{}, error) {
+		attempts++
+		tr.Get(fdb.Key("k")).MustGet()
+		if attempts == 1 {
+			// Commit a conflicting write before this transaction commits
+			if _, err := db.Transact(func(tr Transaction) (interface{}, error) {
+				tr.Set(fdb.Key("k"), []byte("other"))
+				return nil, nil
+			}); err != nil {
+				return nil, err
+			}
+		}
+		tr.Set(fdb.Key("k"), []byte("mine"))
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if attempts != 2 {
+		t.Errorf("expected the transaction to be retried once, got %d attempts", attempts)
+	}
+
+	stop := errors.New("stop")
+	if _, err := db.Transact(func(tr Transaction) (interface{}, error) {
+		return nil, stop
+	}); err != stop {
+		t.Errorf("expected the function's error, got %v", err)
+	}
+
+	_, err = db.ReadTransact(func(rtr ReadTransaction) (interface{}, error) {
+		panic(fdb.ErrKeyTooLarge)
+	})
+	if err != fdb.ErrKeyTooLarge {
+		t.Errorf("expected a panicked error to be returned, got %v", err)
+	}
+}
+
+func TestAtomicOps(t *testing.T) {
+	le := func(n uint64) []byte {
+		b := make([]byte, 8)
+		binary.LittleEndian.PutUint64(b, n)
+		return b
+	}
+
+	testCases := []struct {
+		name    string
+		initial []byte
+		op      func(tr *MemoryTransaction, key fdb.KeyConvertible, param []byte)
+		param   []byte
+		result  []byte
+	}{
+		{"add", le(41), (*MemoryTransaction).Add, le(1), le(42)},
+		{"add missing", nil, (*MemoryTransaction).Add, le(7), le(7)},
+		{"add carry", []byte{0xff, 0x00}, (*MemoryTransaction).Add, []byte{0x01, 0x00}, []byte{0x00, 0x01}},
+		{"max", le(5), (*MemoryTransaction).Max, le(9), le(9)},
+		{"min", le(5), (*MemoryTransaction).Min, le(9), le(5)},
+		{"min missing", nil, (*MemoryTransaction).Min, le(9), le(9)},
+		{"bit and", []byte{0x0f}, (*MemoryTransaction).BitAnd, []byte{0x3c}, []byte{0x0c}},
+		{"bit or", []byte{0x0f}, (*MemoryTransaction).BitOr, []byte{0x30}, []byte{0x3f}},
+		{"bit xor", []byte{0x0f}, (*MemoryTransaction).BitXor, []byte{0xff}, []byte{0xf0}},
+		{"byte max", []byte("abc"), (*MemoryTransaction).ByteMax, []byte("abd"), []byte("abd")},
+		{"byte min", []byte("abc"), (*MemoryTransaction).ByteMin, []byte("abd"), []byte("abc")},
+		{"append", []byte("ab"), (*MemoryTransaction).AppendIfFits, []byte("cd"), []byte("abcd")},
+		{"compare and clear", []byte("x"), (*MemoryTransaction).CompareAndClear, []byte("x"), nil},
+		{"compare and keep", []byte("x"), (*MemoryTransaction).CompareAndClear, []byte("y"), []byte("x")},
+	}
+
+	for _, tc := range testCases {
+		db := NewMemoryDatabase()
+		key := fdb.Key("k")
+
+		if tc.initial != nil {
+			tr := db.CreateTransaction()
+			tr.Set(key, tc.initial)
+			mustCommit(t, tr)
+		}
+
+		// The result is visible to the transaction and once it is committed
+		tr := db.CreateTransaction()
+		tc.op(tr, key, tc.param)
+		if v := tr.Get(key).MustGet(); !bytes.Equal(v, tc.result) {
+			t.Errorf("%s: read %x in the transaction, expected %x", tc.name, v, tc.result)
+		}
+		mustCommit(t, tr)
+
+		if v := db.CreateTransaction().Get(key).MustGet(); !bytes.Equal(v, tc.result) {
+			t.Errorf("%s: read %x after commit, expected %x", tc.name, v, tc.result)
+		}
+	}
+}
+
+// withPlaceholder returns prefix followed by a placeholder for a versionstamp
+// and the little-endian offset of the placeholder.
+func withPlaceholder(prefix []byte) []byte {
+	b := append(append([]byte{}, prefix...), make([]byte, 10)...)
+	return binary.LittleEndian.AppendUint32(b, uint32(len(prefix)))
+}
+
+func TestVersionstamps(t *testing.T) {
+	db := NewMemoryDatabase()
+	sub := subspace.Sub("log")
+
+	tr := db.CreateTransaction()
+	tr.SetVersionstampedKey(fdb.Key(withPlaceholder(sub.Bytes())), []byte("entry"))
+	tr.SetVersionstampedValue(fdb.Key("latest"), withPlaceholder([]byte("v")))
+
+	if _, err := tr.Get(fdb.Key("latest")).Get(); err != fdb.ErrAccessedUnreadable {
+		t.Errorf("expected reading a versionstamped value to fail, got %v", err)
+	}
+	if _, err := tr.GetRange(fdb.KeyRange{Begin: fdb.Key("l"), End: fdb.Key("m")}, fdb.RangeOptions{}).GetSliceWithError(); err != fdb.ErrAccessedUnreadable {
+		t.Errorf("expected reading a range containing a versionstamped value to fail, got %v", err)
+	}
+
+	stamp := tr.GetVersionstamp()
+	mustCommit(t, tr)
+	version, err := tr.GetCommittedVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vs := stamp.MustGet()
+	if len(vs) != 10 || int64(binary.BigEndian.Uint64(vs)) != version {
+		t.Errorf("unexpected versionstamp %x for version %d", vs, version)
+	}
+
+	kvs := db.CreateTransaction().GetRange(sub, fdb.RangeOptions{}).GetSliceOrPanic()
+	if len(kvs) != 1 || !bytes.Equal(kvs[0].Key, append(sub.Bytes(), vs...)) {
+		t.Errorf("unexpected versionstamped keys %q", keys(kvs))
+	}
+
+	if v := db.CreateTransaction().Get(fdb.Key("latest")).MustGet(); !bytes.Equal(v, append([]byte("v"), vs...)) {
+		t.Errorf("unexpected versionstamped value %x", v)
+	}
+}
+
+func TestKeySelectors(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	for _, k := range []string{"b", "d", "f"} {
+		tr.Set(fdb.Key(k), nil)
+	}
+	mustCommit(t, tr)
+
+	tr = db.CreateTransaction()
+	testCases := []struct {
+		sel fdb.Selectable
+		key string
+	}{
+		{fdb.FirstGreaterOrEqual(fdb.Key("d")), "d"},
+		{fdb.FirstGreaterThan(fdb.Key("d")), "f"},
+		{fdb.LastLessOrEqual(fdb.Key("d")), "d"},
+		{fdb.LastLessThan(fdb.Key("d")), "b"},
+		{fdb.FirstGreaterOrEqual(fdb.Key("c")), "d"},
+		{fdb.KeySelector{Key: fdb.Key("b"), OrEqual: false, Offset: 3}, "f"},
+		{fdb.LastLessThan(fdb.Key("b")), ""},
+		{fdb.FirstGreaterThan(fdb.Key("f")), "\xff"},
+	}
+	for _, tc := range testCases {
+		if key := tr.GetKey(tc.sel).MustGet(); string(key) != tc.key {
+			t.Errorf("GetKey(%v) = %q, expected %q", tc.sel, key, tc.key)
+		}
+	}
+
+	sr := fdb.SelectorRange{Begin: fdb.FirstGreaterThan(fdb.Key("b")), End: fdb.FirstGreaterOrEqual(fdb.Key("\xff"))}
+	kvs := tr.GetRange(sr, fdb.RangeOptions{Reverse: true, Limit: 1}).GetSliceOrPanic()
+	if got := keys(kvs); !slices.Equal(got, []string{"f"}) {
+		t.Errorf("unexpected reverse range read %v", got)
+	}
+}
+
+func TestLimits(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	tr.Set(fdb.Key("k"), make([]byte, maxValueSize+1))
+	if err := tr.commit(); err != fdb.ErrValueTooLarge {
+		t.Errorf("expected value_too_large, got %v", err)
+	}
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("\xff/system"), nil)
+	if err := tr.commit(); err != fdb.ErrKeyOutsideLegalRange {
+		t.Errorf("expected key_outside_legal_range, got %v", err)
+	}
+
+	tr = db.CreateTransaction()
+	tr.SetVersionstampedKey(fdb.Key("short"), nil)
+	if err := tr.commit(); err != fdb.ErrClientInvalidOperation {
+		t.Errorf("expected client_invalid_operation, got %v", err)
+	}
+}
+
+func TestCommit(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	tr.Set(fdb.Key("k"), []byte("v"))
+	if err := tr.Commit().Get(); err != nil {
+		t.Fatalf("commit failed: %v", err)
+	}
+	version, err := tr.GetCommittedVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.Commit().Get(); err != nil {
+		t.Errorf("expected a second commit to have no effect, got %v", err)
+	}
+	if v, _ := tr.GetCommittedVersion(); v != version {
+		t.Errorf("expected a second commit to have no effect, got version %d after %d", v, version)
+	}
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("k"), []byte("w"))
+	tr.Cancel()
+	if err := tr.Commit().Get(); err != fdb.ErrTransactionCancelled {
+		t.Errorf("expected committing a cancelled transaction to fail, got %v", err)
+	}
+	if v := db.CreateTransaction().Get(fdb.Key("k")).MustGet(); string(v) != "v" {
+		t.Errorf("expected the write of a cancelled transaction to be discarded, got %q", v)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	w := tr.Watch(fdb.Key("k"))
+	mustCommit(t, tr)
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("other"), []byte("x"))
+	mustCommit(t, tr)
+	if w.IsReady() {
+		t.Fatal("expected a watch to ignore writes to other keys")
+	}
+
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("k"), nil)
+	mustCommit(t, tr)
+	if !w.IsReady() {
+		t.Fatal("expected a watch to fire when its key changes")
+	}
+	if err := w.Get(); err != nil {
+		t.Errorf("expected the watch to succeed, got %v", err)
+	}
+
+	// Setting a key to its current value does not fire a watch
+	tr = db.CreateTransaction()
+	w = tr.Watch(fdb.Key("k"))
+	mustCommit(t, tr)
+	tr = db.CreateTransaction()
+	tr.Set(fdb.Key("k"), nil)
+	mustCommit(t, tr)
+	if w.IsReady() {
+		t.Error("expected a watch to ignore writes of an unchanged value")
+	}
+	w.Cancel()
+	if err := w.Get(); err != fdb.ErrOperationCancelled {
+		t.Errorf("expected a cancelled watch to fail, got %v", err)
+	}
+
+	tr = db.CreateTransaction()
+	w = tr.Watch(fdb.Key("k"))
+	tr.Cancel()
+	if err := w.Get(); err != fdb.ErrTransactionCancelled {
+		t.Errorf("expected the watch of a cancelled transaction to fail, got %v", err)
+	}
+}
+
+func TestDirectory(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	path := []string{"app", "users"}
+	var prefix fdb.Key
+	_, err := db.Transact(func(tr Transaction) (interface{}, error) {
+		dir, err := directory.CreateOrOpen(directory.Using(tr), path, nil)
+		if err != nil {
+			return nil, err
+		}
+		prefix = dir.Bytes()
+		tr.Set(dir.Sub("alice"), []byte("1"))
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.ReadTransact(func(rtr ReadTransaction) (interface{}, error) {
+		exists, err := directory.Exists(directory.UsingRead(rtr), path)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			t.Error("expected the directory to exist")
+		}
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := directory.Open(directory.Using(db.CreateTransaction()), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(dir.Bytes(), prefix) {
+		t.Errorf("expected prefix %x, got %x", prefix, dir.Bytes())
+	}
+}
+
+func TestPage(t *testing.T) {
+	db := NewMemoryDatabase()
+
+	tr := db.CreateTransaction()
+	var all []string
+	for i := 0; i < 25; i++ {
+		key := fmt.Sprintf("k%02d", i)
+		tr.Set(fdb.Key(key), nil)
+		all = append(all, key)
+	}
+	mustCommit(t, tr)
+
+	// readPages reads the range in pages of size, in a new transaction for
+	// each page
+	readPages := func(options fdb.RangeOptions, size int) ([]string, int) {
+		var got []string
+		kr := fdb.KeyRange{Begin: fdb.Key("k"), End: fdb.Key("l")}
+		var r fdb.Range = kr
+		for pages := 1; ; pages++ {
+			kvs, token, err := db.CreateTransaction().GetRange(r, options).Page(size)
+			if err != nil {
+				t.Fatalf("page failed: %v", err)
+			}
+			if len(kvs) > size {
+				t.Fatalf("expected at most %d key-value pairs, got %d", size, len(kvs))
+			}
+			got = append(got, keys(kvs)...)
+			if token == nil {
+				return got, pages
+			}
+			if r, options, err = fdb.ResumeRange(token, kr); err != nil {
+				t.Fatalf("failed to resume range: %v", err)
+			}
+		}
+	}
+
+	got, pages := readPages(fdb.RangeOptions{}, 10)
+	if !slices.Equal(got, all) || pages != 3 {
+		t.Errorf("unexpected keys %v in %d pages", got, pages)
+	}
+
+	// A page ending at the end of the range has no token
+	if _, pages := readPages(fdb.RangeOptions{}, 5); pages != 5 {
+		t.Errorf("expected 5 pages, got %d", pages)
+	}
+
+	got, _ = readPages(fdb.RangeOptions{Limit: 15, Reverse: true}, 4)
+	expected := slices.Clone(all[10:])
+	slices.Reverse(expected)
+	if !slices.Equal(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	// A token is only accepted within the range it is resumed in
+	_, token, err := db.CreateTransaction().GetRange(fdb.KeyRange{Begin: fdb.Key("k"), End: fdb.Key("l")}, fdb.RangeOptions{}).Page(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := fdb.ResumeRange(token, fdb.KeyRange{Begin: fdb.Key("k10"), End: fdb.Key("l")}); err != fdb.ErrInvalidRangeToken {
+		t.Errorf("expected a token outside the range to be rejected, got %v", err)
+	}
+}
@@ -39,6 +39,7 @@ package fdb
 import "C"
 
 import (
+	"context"
 	"runtime"
 	"sync"
 	"unsafe"
@@ -46,9 +47,11 @@ import (
 
 // A Future represents a value (or error) to be available at some later
 // time. Asynchronous FDB API functions return one of the types that implement
-// the Future interface. All Future types additionally implement Get and MustGet
-// methods with different return types. Calling BlockUntilReady, Get or MustGet
-// will block the calling goroutine until the Future is ready.
+// the Future interface. All Future types additionally implement Get, GetContext
+// and MustGet methods with different return types. Calling BlockUntilReady, Get
+// or MustGet will block the calling goroutine until the Future is ready.
+// GetContext additionally returns early, cancelling the Future, if the provided
+// context is done first.
 type Future interface {
 	// BlockUntilReady blocks the calling goroutine until the future is ready. A
 	// future becomes ready either when it receives a value of its enclosed type
@@ -70,6 +73,53 @@ type Future interface {
 	Cancel()
 }
 
+// TypedFuture is a Future whose value is of type T. Every Future type in this
+// package that produces a value satisfies TypedFuture for the type of that
+// value (for example, FutureByteSlice is a TypedFuture[[]byte] and FutureInt64
+// is a TypedFuture[int64]), so that generic code can wait on futures without
+// knowing their concrete type.
+type TypedFuture[T any] interface {
+	// Get returns the value of the future, or an error if the asynchronous
+	// operation associated with this future did not successfully complete. The
+	// current goroutine will be blocked until the future is ready.
+	Get() (T, error)
+
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) (T, error)
+
+	// MustGet returns the value of the future, or panics if the asynchronous
+	// operation associated with this future did not successfully complete. The
+	// current goroutine will be blocked until the future is ready.
+	MustGet() T
+
+	Future
+}
+
+var (
+	_ TypedFuture[[]byte]   = FutureByteSlice(nil)
+	_ TypedFuture[Key]      = FutureKey(nil)
+	_ TypedFuture[[]Key]    = FutureKeyArray(nil)
+	_ TypedFuture[int64]    = FutureInt64(nil)
+	_ TypedFuture[uint64]   = FutureUint64(nil)
+	_ TypedFuture[float64]  = FutureFloat64(nil)
+	_ TypedFuture[[]string] = FutureStringSlice(nil)
+)
+
+// GetAll waits for all of the provided futures and returns their values in the
+// same order, or the first error encountered.
+func GetAll[T any](futures ...TypedFuture[T]) ([]T, error) {
+	ret := make([]T, len(futures))
+	for i, f := range futures {
+		v, err := f.Get()
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = v
+	}
+	return ret, nil
+}
+
 type future struct {
 	// db is used to hint Go's GC about the dependency on the parent database object.
 	// This prevents the database to be garbage-collected before future is out of scope.
@@ -118,6 +168,44 @@ func (f *future) BlockUntilReady() {
 	fdb_future_block_until_ready(f.ptr)
 }
 
+// blockUntilReadyContext blocks the calling goroutine until the future is
+// ready or ctx is done, whichever happens first. If ctx is done first the
+// future is cancelled and ctx.Err() is returned.
+func (f *future) blockUntilReadyContext(ctx context.Context) error {
+	// contexts that can never be done (such as context.Background) don't need
+	// the additional goroutine
+	if ctx.Done() == nil {
+		f.BlockUntilReady()
+		return nil
+	}
+
+	if f.IsReady() {
+		return nil
+	}
+
+	if err := ctx.Err(); err != nil {
+		f.Cancel()
+		return err
+	}
+
+	ready := make(chan struct{})
+	go func() {
+		f.BlockUntilReady()
+		close(ready)
+	}()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		// Cancelling the future sets it to an error state, which in turn fires
+		// the callback the goroutine above is waiting on.
+		f.Cancel()
+		<-ready
+		return ctx.Err()
+	}
+}
+
 func (f *future) IsReady() bool {
 	defer runtime.KeepAlive(f)
 	return C.fdb_future_is_ready(f.ptr) != 0
@@ -138,6 +226,10 @@ type FutureByteSlice interface {
 	// future is ready.
 	Get() ([]byte, error)
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) ([]byte, error)
+
 	// MustGet returns a database value (or nil if there is no value), or panics
 	// if the asynchronous operation associated with this future did not
 	// successfully complete. The current goroutine will be blocked until the
@@ -179,6 +271,13 @@ func (f *futureByteSlice) Get() ([]byte, error) {
 	return f.v, f.e
 }
 
+func (f *futureByteSlice) GetContext(ctx context.Context) ([]byte, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return nil, err
+	}
+	return f.Get()
+}
+
 func (f *futureByteSlice) MustGet() []byte {
 	val, err := f.Get()
 	if err != nil {
@@ -196,6 +295,10 @@ type FutureKey interface {
 	// goroutine will be blocked until the future is ready.
 	Get() (Key, error)
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) (Key, error)
+
 	// MustGet returns a database key, or panics if the asynchronous operation
 	// associated with this future did not successfully complete. The current
 	// goroutine will be blocked until the future is ready.
@@ -232,6 +335,13 @@ func (f *futureKey) Get() (Key, error) {
 	return f.k, f.e
 }
 
+func (f *futureKey) GetContext(ctx context.Context) (Key, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return nil, err
+	}
+	return f.Get()
+}
+
 func (f *futureKey) MustGet() Key {
 	val, err := f.Get()
 	if err != nil {
@@ -249,6 +359,10 @@ type FutureNil interface {
 	// blocked until the future is ready.
 	Get() error
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) error
+
 	// MustGet panics if the asynchronous operation associated with this future
 	// did not successfully complete. The current goroutine will be blocked
 	// until the future is ready.
@@ -272,6 +386,13 @@ func (f *futureNil) Get() error {
 	return nil
 }
 
+func (f *futureNil) GetContext(ctx context.Context) error {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return err
+	}
+	return f.Get()
+}
+
 func (f *futureNil) MustGet() {
 	if err := f.Get(); err != nil {
 		panic(err)
@@ -320,6 +441,61 @@ func (f *futureKeyValueArray) Get() ([]KeyValue, bool, error) {
 	return ret, (more != 0), nil
 }
 
+type futureMappedKeyValueArray struct {
+	*future
+}
+
+func stringRefToKeySelector(ptr unsafe.Pointer) KeySelector {
+	return KeySelector{
+		Key:     Key(stringRefToSlice(ptr)),
+		OrEqual: *((*C.fdb_bool_t)(unsafe.Pointer(uintptr(ptr) + 12))) != 0,
+		Offset:  int(*((*C.int)(unsafe.Pointer(uintptr(ptr) + 16)))),
+	}
+}
+
+func (f *futureMappedKeyValueArray) Get() ([]MappedKeyValue, bool, error) {
+	defer runtime.KeepAlive(f.future)
+
+	f.BlockUntilReady()
+
+	var mkvs *C.FDBMappedKeyValue
+	var count C.int
+	var more C.fdb_bool_t
+
+	if err := C.fdb_future_get_mappedkeyvalue_array(f.ptr, &mkvs, &count, &more); err != 0 {
+		return nil, false, Error{int(err)}
+	}
+
+	ret := make([]MappedKeyValue, int(count))
+
+	for i := 0; i < int(count); i++ {
+		mkvptr := unsafe.Pointer(uintptr(unsafe.Pointer(mkvs)) + uintptr(i*112))
+
+		ret[i].Key = stringRefToSlice(mkvptr)
+		ret[i].Value = stringRefToSlice(unsafe.Pointer(uintptr(mkvptr) + 12))
+
+		// The mapped range request and its result start at offset 24: two key
+		// selectors of 20 bytes each, followed by the (aligned) array of
+		// key-value pairs and its length.
+		ret[i].RangeBegin = stringRefToKeySelector(unsafe.Pointer(uintptr(mkvptr) + 24))
+		ret[i].RangeEnd = stringRefToKeySelector(unsafe.Pointer(uintptr(mkvptr) + 44))
+
+		kvs := *(**C.FDBKeyValue)(unsafe.Pointer(uintptr(mkvptr) + 64))
+		size := int(*((*C.int)(unsafe.Pointer(uintptr(mkvptr) + 72))))
+
+		ret[i].RangeResult = make([]KeyValue, size)
+
+		for j := 0; j < size; j++ {
+			kvptr := unsafe.Pointer(uintptr(unsafe.Pointer(kvs)) + uintptr(j*24))
+
+			ret[i].RangeResult[j].Key = stringRefToSlice(kvptr)
+			ret[i].RangeResult[j].Value = stringRefToSlice(unsafe.Pointer(uintptr(kvptr) + 12))
+		}
+	}
+
+	return ret, (more != 0), nil
+}
+
 // FutureKeyArray represents the asynchronous result of a function
 // that returns an array of keys. FutureKeyArray is a lightweight object
 // that may be efficiently copied, and is safe for concurrent use by multiple goroutines.
@@ -330,10 +506,16 @@ type FutureKeyArray interface {
 	// goroutine will be blocked until the future is ready.
 	Get() ([]Key, error)
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) ([]Key, error)
+
 	// MustGet returns an array of keys, or panics if the asynchronous operations
 	// associated with this future did not successfully complete. The current goroutine
 	// will be blocked until the future is ready.
 	MustGet() []Key
+
+	Future
 }
 
 type futureKeyArray struct {
@@ -363,6 +545,13 @@ func (f *futureKeyArray) Get() ([]Key, error) {
 	return ret, nil
 }
 
+func (f *futureKeyArray) GetContext(ctx context.Context) ([]Key, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return nil, err
+	}
+	return f.Get()
+}
+
 func (f *futureKeyArray) MustGet() []Key {
 	val, err := f.Get()
 	if err != nil {
@@ -380,6 +569,10 @@ type FutureInt64 interface {
 	// goroutine will be blocked until the future is ready.
 	Get() (int64, error)
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) (int64, error)
+
 	// MustGet returns a database version, or panics if the asynchronous
 	// operation associated with this future did not successfully complete. The
 	// current goroutine will be blocked until the future is ready.
@@ -405,6 +598,13 @@ func (f *futureInt64) Get() (int64, error) {
 	return int64(ver), nil
 }
 
+func (f *futureInt64) GetContext(ctx context.Context) (int64, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return 0, err
+	}
+	return f.Get()
+}
+
 func (f *futureInt64) MustGet() int64 {
 	val, err := f.Get()
 	if err != nil {
@@ -413,6 +613,113 @@ func (f *futureInt64) MustGet() int64 {
 	return val
 }
 
+// FutureUint64 represents the asynchronous result of a function that returns an
+// unsigned integer, such as a protocol version. FutureUint64 is a lightweight
+// object that may be efficiently copied, and is safe for concurrent use by
+// multiple goroutines.
+type FutureUint64 interface {
+	// Get returns an unsigned integer or an error if the asynchronous operation
+	// associated with this future did not successfully complete. The current
+	// goroutine will be blocked until the future is ready.
+	Get() (uint64, error)
+
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) (uint64, error)
+
+	// MustGet returns an unsigned integer, or panics if the asynchronous
+	// operation associated with this future did not successfully complete. The
+	// current goroutine will be blocked until the future is ready.
+	MustGet() uint64
+
+	Future
+}
+
+type futureUint64 struct {
+	*future
+}
+
+func (f *futureUint64) Get() (uint64, error) {
+	defer runtime.KeepAlive(f.future)
+
+	f.BlockUntilReady()
+
+	var val C.uint64_t
+	if err := C.fdb_future_get_uint64(f.ptr, &val); err != 0 {
+		return 0, Error{int(err)}
+	}
+
+	return uint64(val), nil
+}
+
+func (f *futureUint64) GetContext(ctx context.Context) (uint64, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return 0, err
+	}
+	return f.Get()
+}
+
+func (f *futureUint64) MustGet() uint64 {
+	val, err := f.Get()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// FutureFloat64 represents the asynchronous result of a function that returns a
+// floating point value. FutureFloat64 is a lightweight object that may be
+// efficiently copied, and is safe for concurrent use by multiple goroutines.
+type FutureFloat64 interface {
+	// Get returns a floating point value or an error if the asynchronous
+	// operation associated with this future did not successfully complete. The
+	// current goroutine will be blocked until the future is ready.
+	Get() (float64, error)
+
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) (float64, error)
+
+	// MustGet returns a floating point value, or panics if the asynchronous
+	// operation associated with this future did not successfully complete. The
+	// current goroutine will be blocked until the future is ready.
+	MustGet() float64
+
+	Future
+}
+
+type futureFloat64 struct {
+	*future
+}
+
+func (f *futureFloat64) Get() (float64, error) {
+	defer runtime.KeepAlive(f.future)
+
+	f.BlockUntilReady()
+
+	var val C.double
+	if err := C.fdb_future_get_double(f.ptr, &val); err != 0 {
+		return 0, Error{int(err)}
+	}
+
+	return float64(val), nil
+}
+
+func (f *futureFloat64) GetContext(ctx context.Context) (float64, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return 0, err
+	}
+	return f.Get()
+}
+
+func (f *futureFloat64) MustGet() float64 {
+	val, err := f.Get()
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
 // FutureStringSlice represents the asynchronous result of a function that
 // returns a slice of strings. FutureStringSlice is a lightweight object that
 // may be efficiently copied, and is safe for concurrent use by multiple
@@ -423,6 +730,10 @@ type FutureStringSlice interface {
 	// goroutine will be blocked until the future is ready.
 	Get() ([]string, error)
 
+	// GetContext is like Get, but returns ctx.Err() and cancels the future if
+	// ctx is done before the future is ready.
+	GetContext(ctx context.Context) ([]string, error)
+
 	// MustGet returns a slice of strings or panics if the asynchronous
 	// operation associated with this future did not successfully complete. The
 	// current goroutine will be blocked until the future is ready.
@@ -456,6 +767,13 @@ func (f *futureStringSlice) Get() ([]string, error) {
 	return ret, nil
 }
 
+func (f *futureStringSlice) GetContext(ctx context.Context) ([]string, error) {
+	if err := f.blockUntilReadyContext(ctx); err != nil {
+		return nil, err
+	}
+	return f.Get()
+}
+
 func (f *futureStringSlice) MustGet() []string {
 	val, err := f.Get()
 	if err != nil {
@@ -0,0 +1,190 @@
+/*
+ * idempotent.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+)
+
+// Idempotency markers are stored under a caller-provided prefix as two keys
+// per transaction: prefix + markerByID + id, whose value is the number of the
+// attempt that committed, and prefix + markerByVersion + versionstamp + id,
+// which orders markers by commit version so that old ones can be cleared.
+const (
+	markerByID      = 0x00
+	markerByVersion = 0x01
+
+	markerIDLength = 16
+
+	// The cluster advances its version at approximately this rate, which
+	// allows a marker age to be converted into a version.
+	versionsPerSecond = 1000000
+)
+
+// TransactIdempotent is like the Transact method of t, but guarantees that the
+// effects of f are committed at most once, even if a commit fails with
+// commit_unknown_result and is retried. This makes it safe to use with
+// non-idempotent writes such as (Transaction).Add.
+//
+// Each commit also writes a small marker under markers. When an attempt is
+// retried after f has returned successfully, the marker is read first: if it
+// shows that an earlier attempt committed, TransactIdempotent returns the value
+// returned by f in that attempt without running f again. Markers must be
+// cleared periodically with CleanIdempotencyMarkers or
+// RunIdempotencyMarkerCleaner, using a maximum age comfortably longer than any
+// transaction retry loop. The markers prefix should not be used for any other
+// data.
+//
+// Clusters that support it may instead use the automatic idempotency
+// transaction option (see (TransactionOptions).SetAutomaticIdempotency), which
+// does not require markers but is not yet ready for general use.
+func TransactIdempotent(t Transactor, markers KeyConvertible, f func(Transaction) (interface{}, error)) (interface{}, error) {
+	id := make([]byte, markerIDLength)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	prefix := markers.FDBKey()
+	idKey := markerKey(prefix, markerByID, id)
+
+	// The versionstamp is written between the prefix and the id.
+	versionKey := markerKey(prefix, markerByVersion, make([]byte, 10), id)
+	versionKey = binary.LittleEndian.AppendUint32(versionKey, uint32(len(prefix)+1))
+
+	attempt := 0
+	committing := false
+	results := make(map[uint64]interface{})
+
+	return t.Transact(func(tr Transaction) (interface{}, error) {
+		attempt++
+
+		// An earlier attempt may have committed if f returned successfully
+		if committing {
+			v, err := tr.Get(idKey).Get()
+			if err != nil {
+				return nil, err
+			}
+			if len(v) == 8 {
+				return results[binary.BigEndian.Uint64(v)], nil
+			}
+		}
+
+		ret, err := f(tr)
+		if err != nil {
+			return nil, err
+		}
+
+		value := binary.BigEndian.AppendUint64(nil, uint64(attempt))
+		tr.Set(idKey, value)
+		tr.SetVersionstampedKey(Key(versionKey), nil)
+
+		committing = true
+		results[uint64(attempt)] = ret
+		return ret, nil
+	})
+}
+
+// CleanIdempotencyMarkers clears the markers written by TransactIdempotent
+// under markers that were committed more than maxAge ago, and returns the
+// number of markers cleared.
+func CleanIdempotencyMarkers(t Transactor, markers KeyConvertible, maxAge time.Duration) (int, error) {
+	prefix := markers.FDBKey()
+	begin := markerKey(prefix, markerByVersion)
+
+	var cutoff Key
+	cleared := 0
+
+	for {
+		ret, err := t.Transact(func(tr Transaction) (interface{}, error) {
+			if cutoff == nil {
+				rv, err := tr.GetReadVersion().Get()
+				if err != nil {
+					return nil, err
+				}
+				version := rv - int64(maxAge.Seconds()*versionsPerSecond)
+				if version <= 0 {
+					return 0, nil
+				}
+				cutoff = markerKey(prefix, markerByVersion, binary.BigEndian.AppendUint64(nil, uint64(version)))
+			}
+
+			kr := KeyRange{begin, cutoff}
+			kvs, err := tr.GetRange(kr, RangeOptions{Limit: 1000}).GetSliceWithError()
+			if err != nil {
+				return nil, err
+			}
+			if len(kvs) == 0 {
+				return 0, nil
+			}
+
+			for _, kv := range kvs {
+				id := kv.Key[len(begin)+10:]
+				tr.Clear(markerKey(prefix, markerByID, id))
+			}
+			tr.ClearRange(KeyRange{begin, append(kvs[len(kvs)-1].Key, 0x00)})
+
+			return len(kvs), nil
+		})
+		if err != nil {
+			return cleared, err
+		}
+
+		n := ret.(int)
+		if n == 0 {
+			return cleared, nil
+		}
+		cleared += n
+	}
+}
+
+// RunIdempotencyMarkerCleaner calls CleanIdempotencyMarkers every interval
+// until ctx is done, and is intended to be run in its own goroutine. It returns
+// ctx.Err() once ctx is done, or the first error returned by
+// CleanIdempotencyMarkers.
+func RunIdempotencyMarkerCleaner(ctx context.Context, t Transactor, markers KeyConvertible, maxAge, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := CleanIdempotencyMarkers(t, markers, maxAge); err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func markerKey(prefix Key, kind byte, parts ...[]byte) Key {
+	key := append(append(Key{}, prefix...), kind)
+	for _, p := range parts {
+		key = append(key, p...)
+	}
+	return key
+}
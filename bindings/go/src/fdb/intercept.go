@@ -0,0 +1,109 @@
+/*
+ * intercept.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"context"
+)
+
+// TransactInfo describes a call to one of the Transact or ReadTransact methods
+// of a Database or Tenant observed by an Interceptor.
+type TransactInfo struct {
+	// Context is the context passed to TransactContext or ReadTransactContext,
+	// or context.Background() for Transact and ReadTransact.
+	Context context.Context
+
+	// ReadOnly is true for ReadTransact and ReadTransactContext.
+	ReadOnly bool
+
+	// Transaction is the transaction passed to the transactional function. It
+	// may be used to query the transaction, for example with
+	// GetApproximateSize or GetCommittedVersion, but should not be modified.
+	Transaction Transaction
+}
+
+// AttemptInfo describes a single attempt of a transactional function observed
+// by an Interceptor.
+type AttemptInfo struct {
+	TransactInfo
+
+	// Attempt is the number of this attempt, starting at 1.
+	Attempt int
+}
+
+// Interceptor observes, and may wrap, the transactions run by the Transact and
+// ReadTransact methods of a Database (and of tenants opened from it). Each
+// field is optional, and is called with a next function which runs the
+// intercepted operation and returns its error. An interceptor must call next
+// exactly once, and would usually return the error it returns.
+//
+// Interceptors are attached to a Database with (Database).WithInterceptors.
+type Interceptor struct {
+	// Transact wraps a whole call to Transact or ReadTransact, including all
+	// of its attempts.
+	Transact func(info TransactInfo, next func() error) error
+
+	// Attempt wraps each attempt of the transactional function, including the
+	// commit. A non-nil error returned by next is passed to OnError (or the
+	// retry policy of the database) to decide whether to retry.
+	Attempt func(info AttemptInfo, next func() error) error
+
+	// Commit wraps the commit at the end of each attempt of Transact. For
+	// example, an interceptor may call GetApproximateSize before calling next,
+	// and GetCommittedVersion after next returns successfully.
+	Commit func(info AttemptInfo, next func() error) error
+}
+
+// WithInterceptors returns a copy of the database handle whose Transact and
+// ReadTransact methods (and those of tenants opened from it) are wrapped by the
+// given interceptors in addition to any already attached. The receiver is not
+// modified. The first interceptor given is the outermost.
+func (d Database) WithInterceptors(interceptors ...Interceptor) Database {
+	current := d.getInterceptors()
+	chain := append(current[:len(current):len(current)], interceptors...)
+	d.interceptors = &chain
+	return d
+}
+
+func (d Database) getInterceptors() []Interceptor {
+	if d.interceptors == nil {
+		return nil
+	}
+	return *d.interceptors
+}
+
+// intercept runs next wrapped by the hooks of interceptors selected by hook,
+// with the first interceptor outermost.
+func intercept[T any](interceptors []Interceptor, hook func(Interceptor) func(T, func() error) error, info T, next func() error) error {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		if h := hook(interceptors[i]); h != nil {
+			inner := next
+			next = func() error { return h(info, inner) }
+		}
+	}
+	return next()
+}
+
+func transactHook(i Interceptor) func(TransactInfo, func() error) error { return i.Transact }
+func attemptHook(i Interceptor) func(AttemptInfo, func() error) error   { return i.Attempt }
+func commitHook(i Interceptor) func(AttemptInfo, func() error) error    { return i.Commit }
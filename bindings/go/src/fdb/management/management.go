@@ -0,0 +1,305 @@
+/*
+ * management.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Cluster Management
+
+// Package management provides functions for administering a FoundationDB
+// cluster, equivalent to the exclude, include, maintenance and
+// datadistribution commands of fdbcli.
+//
+// All operations are performed by reading and writing the \xff\xff/management/
+// module of the special key space. When the cluster rejects an operation, the
+// returned error is an *Error describing why, as read from
+// \xff\xff/error_message.
+//
+// Changing the database configuration, as with the configure command of
+// fdbcli, is not supported. The special key space has no module for the
+// database configuration, and the validation performed by fdbcli before it
+// writes a new configuration is not available through the C API.
+//
+// For general guidance on the special key space, see
+// https://apple.github.io/foundationdb/special-keys.html.
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+const (
+	managementPrefix = "\xff\xff/management/"
+	errorMessageKey  = "\xff\xff/error_message"
+
+	excludedPrefix         = managementPrefix + "excluded/"
+	failedPrefix           = managementPrefix + "failed/"
+	excludedLocalityPrefix = managementPrefix + "excluded_locality/"
+	failedLocalityPrefix   = managementPrefix + "failed_locality/"
+	inProgressPrefix       = managementPrefix + "in_progress_exclusion/"
+	maintenancePrefix      = managementPrefix + "maintenance/"
+	dataDistributionMode   = managementPrefix + "data_distribution/mode"
+
+	// Exclusions of this form refer to processes by locality rather than by
+	// address, for example locality_zoneid:z1.
+	localityExclusionPrefix = "locality_"
+)
+
+// Error is returned when the cluster rejects a management operation, and
+// describes why. It wraps fdb.ErrSpecialKeysApiFailure.
+type Error struct {
+	// Command is the management command that failed, such as "exclude".
+	Command string `json:"command"`
+
+	// Message describes why the command failed.
+	Message string `json:"message"`
+
+	// Retriable reports whether the command may succeed if attempted again.
+	Retriable bool `json:"retriable"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s failed: %s", e.Command, e.Message)
+}
+
+// Unwrap returns fdb.ErrSpecialKeysApiFailure.
+func (e *Error) Unwrap() error {
+	return fdb.ErrSpecialKeysApiFailure
+}
+
+// Exclusions lists the processes that are excluded from the cluster, and those
+// whose exclusion has not yet completed.
+type Exclusions struct {
+	// Excluded lists the addresses and localities which have been excluded.
+	Excluded []string
+
+	// Failed lists the addresses and localities which have been excluded as
+	// failed.
+	Failed []string
+
+	// InProgress lists the addresses of excluded processes which still hold
+	// data or are still part of the transaction system. Processes which are
+	// not in InProgress may be safely removed from the cluster.
+	InProgress []string
+}
+
+// run runs f in a transaction with writes to the special key space enabled,
+// and commits it, retrying as necessary until ctx is done. If the cluster
+// rejects the commit, the reason is read from the transaction and returned as
+// an *Error.
+func run(ctx context.Context, db fdb.Database, f func(tr fdb.Transaction) error) error {
+	tr, err := db.CreateTransaction()
+	if err != nil {
+		return err
+	}
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := tr.Options().SetSpecialKeySpaceEnableWrites()
+		if err == nil {
+			err = f(tr)
+		}
+		if err == nil {
+			err = tr.Commit().GetContext(ctx)
+		}
+		if err == nil {
+			return nil
+		}
+
+		var ep fdb.Error
+		if !errors.As(err, &ep) {
+			return err
+		}
+		if ep == fdb.ErrSpecialKeysApiFailure {
+			return errorMessage(tr, ep)
+		}
+		if err := tr.OnError(ep).GetContext(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// errorMessage reads the reason for the failure of tr from the special key
+// space, returning cause if it cannot be read.
+func errorMessage(tr fdb.Transaction, cause fdb.Error) error {
+	v, err := tr.Get(fdb.Key(errorMessageKey)).Get()
+	if err != nil || v == nil {
+		return cause
+	}
+	return parseErrorMessage(v, cause)
+}
+
+func parseErrorMessage(v []byte, cause fdb.Error) error {
+	e := &Error{}
+	if err := json.Unmarshal(v, e); err != nil {
+		return cause
+	}
+	return e
+}
+
+// exclusionKey returns the key under which addr is excluded. Addresses
+// beginning with locality_ are excluded by locality.
+func exclusionKey(addr string, failed bool) fdb.Key {
+	locality := strings.HasPrefix(addr, localityExclusionPrefix)
+	switch {
+	case locality && failed:
+		return fdb.Key(failedLocalityPrefix + addr)
+	case locality:
+		return fdb.Key(excludedLocalityPrefix + addr)
+	case failed:
+		return fdb.Key(failedPrefix + addr)
+	default:
+		return fdb.Key(excludedPrefix + addr)
+	}
+}
+
+func prefixRange(prefix string) fdb.KeyRange {
+	return fdb.KeyRange{Begin: fdb.Key(prefix), End: fdb.Key(prefix[:len(prefix)-1] + "0")}
+}
+
+// Exclude excludes the processes with the given addresses from the cluster,
+// causing their data to be moved to other processes and their roles to be
+// recruited elsewhere. An address may be an IP address, which excludes every
+// process on that host, an IP:port pair, or a locality such as
+// locality_zoneid:z1.
+//
+// If failed is true the processes are marked as failed, and their data is
+// assumed to be lost: data is re-replicated from the remaining replicas
+// without waiting for the processes to be reachable. This should only be used
+// for processes which are permanently gone.
+//
+// The cluster refuses the exclusion if it would leave too little space or too
+// few processes to satisfy the replication policy. Exclude returns once the
+// exclusion has been recorded; use ExclusionStatus to determine when the
+// processes may be safely removed.
+func Exclude(ctx context.Context, db fdb.Database, addrs []string, failed bool) error {
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		for _, addr := range addrs {
+			tr.Set(exclusionKey(addr, failed), nil)
+		}
+		return nil
+	})
+}
+
+// Include reverses the exclusion of the processes with the given addresses,
+// which must be given as they were to Exclude. If failed is true, the
+// addresses are removed from the list of failed processes rather than from the
+// list of excluded processes.
+func Include(ctx context.Context, db fdb.Database, addrs []string, failed bool) error {
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		for _, addr := range addrs {
+			tr.Clear(exclusionKey(addr, failed))
+		}
+		return nil
+	})
+}
+
+// IncludeAll reverses the exclusion of all excluded processes, or of all
+// failed processes if failed is true.
+func IncludeAll(ctx context.Context, db fdb.Database, failed bool) error {
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		if failed {
+			tr.ClearRange(prefixRange(failedPrefix))
+			tr.ClearRange(prefixRange(failedLocalityPrefix))
+		} else {
+			tr.ClearRange(prefixRange(excludedPrefix))
+			tr.ClearRange(prefixRange(excludedLocalityPrefix))
+		}
+		return nil
+	})
+}
+
+// ExclusionStatus returns the processes that are excluded from the cluster,
+// and those whose exclusion is still in progress.
+func ExclusionStatus(ctx context.Context, db fdb.Database) (Exclusions, error) {
+	ret, err := db.ReadTransactContext(ctx, func(rtr fdb.ReadTransaction) (interface{}, error) {
+		var st Exclusions
+		lists := []struct {
+			prefix string
+			list   *[]string
+		}{
+			{excludedPrefix, &st.Excluded},
+			{excludedLocalityPrefix, &st.Excluded},
+			{failedPrefix, &st.Failed},
+			{failedLocalityPrefix, &st.Failed},
+			{inProgressPrefix, &st.InProgress},
+		}
+
+		futures := make([]fdb.RangeResult, len(lists))
+		for i, l := range lists {
+			futures[i] = rtr.GetRange(prefixRange(l.prefix), fdb.RangeOptions{})
+		}
+
+		for i, l := range lists {
+			kvs, err := futures[i].GetSliceWithError()
+			if err != nil {
+				return nil, err
+			}
+			for _, kv := range kvs {
+				*l.list = append(*l.list, string(kv.Key[len(l.prefix):]))
+			}
+		}
+		return st, nil
+	})
+	if err != nil {
+		return Exclusions{}, err
+	}
+	return ret.(Exclusions), nil
+}
+
+// SetMaintenance puts the given zone into maintenance for duration, during
+// which the failure of its storage servers does not cause data to be
+// re-replicated. Only one zone may be in maintenance at a time; setting a new
+// zone ends the maintenance of the previous one.
+func SetMaintenance(ctx context.Context, db fdb.Database, zone string, duration time.Duration) error {
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		tr.Set(fdb.Key(maintenancePrefix+zone), []byte(strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)))
+		return nil
+	})
+}
+
+// ClearMaintenance ends the maintenance of any zone.
+func ClearMaintenance(ctx context.Context, db fdb.Database) error {
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		tr.ClearRange(prefixRange(maintenancePrefix))
+		return nil
+	})
+}
+
+// SetDataDistribution enables or disables data distribution, which moves data
+// between storage servers to balance load and to recover from failures.
+func SetDataDistribution(ctx context.Context, db fdb.Database, enabled bool) error {
+	mode := "0"
+	if enabled {
+		mode = "1"
+	}
+	return run(ctx, db, func(tr fdb.Transaction) error {
+		tr.Set(fdb.Key(dataDistributionMode), []byte(mode))
+		return nil
+	})
+}
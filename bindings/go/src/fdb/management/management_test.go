@@ -0,0 +1,59 @@
+package management
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+func TestExclusionKey(t *testing.T) {
+	testCases := []struct {
+		addr   string
+		failed bool
+		key    string
+	}{
+		{"10.0.0.1", false, "\xff\xff/management/excluded/10.0.0.1"},
+		{"10.0.0.1:4500", true, "\xff\xff/management/failed/10.0.0.1:4500"},
+		{"locality_zoneid:z1", false, "\xff\xff/management/excluded_locality/locality_zoneid:z1"},
+		{"locality_zoneid:z1", true, "\xff\xff/management/failed_locality/locality_zoneid:z1"},
+	}
+
+	for _, tc := range testCases {
+		if key := exclusionKey(tc.addr, tc.failed); string(key) != tc.key {
+			t.Errorf("exclusionKey(%q, %t) = %q, expected %q", tc.addr, tc.failed, key, tc.key)
+		}
+	}
+}
+
+func TestPrefixRange(t *testing.T) {
+	begin, end := prefixRange(excludedPrefix).FDBRangeKeys()
+	if !bytes.Equal(begin.FDBKey(), []byte("\xff\xff/management/excluded/")) {
+		t.Errorf("unexpected range begin %v", begin)
+	}
+	if !bytes.Equal(end.FDBKey(), []byte("\xff\xff/management/excluded0")) {
+		t.Errorf("unexpected range end %v", end)
+	}
+}
+
+func TestParseErrorMessage(t *testing.T) {
+	msg := `{"retriable": false, "command": "exclude", "message": "ERROR: It is unsafe to exclude the specified servers at this time."}`
+
+	err := parseErrorMessage([]byte(msg), fdb.ErrSpecialKeysApiFailure)
+
+	var me *Error
+	if !errors.As(err, &me) {
+		t.Fatalf("expected a management error, got %v", err)
+	}
+	if me.Command != "exclude" || me.Retriable {
+		t.Errorf("unexpected error %+v", me)
+	}
+	if !errors.Is(err, fdb.ErrSpecialKeysApiFailure) {
+		t.Error("expected the error to wrap special_keys_api_failure")
+	}
+
+	if err := parseErrorMessage([]byte("not json"), fdb.ErrSpecialKeysApiFailure); err != fdb.ErrSpecialKeysApiFailure {
+		t.Errorf("expected an unparseable message to return the cause, got %v", err)
+	}
+}
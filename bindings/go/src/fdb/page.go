@@ -0,0 +1,212 @@
+/*
+ * page.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+const (
+	rangeTokenVersion = 1
+
+	rangeTokenReverse = 1 << 0
+)
+
+// ErrInvalidRangeToken is returned by ResumeRange and
+// (*RangeToken).UnmarshalText when a RangeToken is malformed, and by
+// ResumeRange when the position recorded by a RangeToken is outside the range
+// passed to it.
+var ErrInvalidRangeToken = errors.New("invalid range token")
+
+// RangeToken is an opaque continuation token returned by (RangeResult).Page,
+// from which ResumeRange reconstructs the remainder of a paged range read. A
+// RangeToken does not depend on the transaction that returned it, and may be
+// stored or passed to a client to continue the read in a later transaction.
+//
+// The text form of a RangeToken, as returned by String and MarshalText, is safe
+// to use in URLs and JSON documents.
+//
+// A RangeToken records the last key returned, the direction of the read and
+// the number of key-value pairs remaining from its limit. It is not signed or
+// encrypted, so a client holding a token can read the last key from it and can
+// replace the token with one recording any other position or limit. ResumeRange
+// only accepts a token whose position is within the range passed to it, which
+// should therefore be the range that the client is allowed to read.
+type RangeToken []byte
+
+// String returns the text form of the token.
+func (t RangeToken) String() string {
+	return base64.RawURLEncoding.EncodeToString(t)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (t RangeToken) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *RangeToken) UnmarshalText(text []byte) error {
+	b, err := base64.RawURLEncoding.DecodeString(string(text))
+	if err != nil {
+		return ErrInvalidRangeToken
+	}
+	if _, _, _, err := decodeRangeToken(b); err != nil {
+		return err
+	}
+	*t = b
+	return nil
+}
+
+// Page reads at most limit key-value pairs of the range specified in the read
+// that returned this RangeResult. If the range may contain more key-value
+// pairs, Page also returns a RangeToken from which ResumeRange reconstructs the
+// rest of the read, to be continued in the same or another transaction. If the
+// range has been exhausted, or the Limit of its RangeOptions has been reached,
+// the returned token is nil.
+//
+// Page does not fetch another batch once the page is full, but the first batch
+// is requested by GetRange, before the page limit is known, and each batch may
+// contain more key-value pairs than the page needs. The work done by each page
+// is therefore bounded by limit only if the read uses a streaming mode with
+// small batches, such as StreamingModeSmall; with StreamingModeWantAll, the
+// first batch may contain the whole range.
+//
+// Since the token records only the last key returned, keys written between
+// pages are returned by a later page if they follow that key, and are skipped
+// otherwise.
+func (rr RangeResult) Page(limit int) ([]KeyValue, RangeToken, error) {
+	if limit <= 0 {
+		return nil, nil, errors.New("page limit must be positive")
+	}
+
+	var kvs []KeyValue
+	ri := rr.Iterator()
+	for len(kvs) < limit && ri.Advance() {
+		if ri.err != nil {
+			return nil, nil, ri.err
+		}
+		n := min(limit-len(kvs), len(ri.kvs)-ri.index)
+		kvs = append(kvs, ri.kvs[ri.index:ri.index+n]...)
+		ri.index += n
+
+		// The next batch is only fetched if the page is not yet full, so that
+		// a page ending at the end of a batch does not read ahead
+		if ri.index == len(ri.kvs) && len(kvs) < limit {
+			ri.fetchNextBatch()
+		}
+	}
+
+	more := len(kvs) == limit && !ri.done &&
+		(ri.index < len(ri.kvs) || (ri.more && ri.index != ri.options.Limit))
+	if !more {
+		return kvs, nil, nil
+	}
+
+	remaining := 0
+	if rr.options.Limit > 0 {
+		remaining = rr.options.Limit - len(kvs)
+	}
+
+	return kvs, encodeRangeToken(kvs[len(kvs)-1].Key, rr.options.Reverse, remaining), nil
+}
+
+// ResumeRange returns the range and the options of the read continued by a
+// RangeToken returned by (RangeResult).Page, which must be within er. The range
+// begins after (or, for a reverse read, ends before) the last key returned by
+// the previous page, and ends at the end (or begins at the beginning) of er.
+// The Limit of the options is the number of key-value pairs remaining from the
+// limit of the original read, or 0 if it had none.
+//
+// ResumeRange returns ErrInvalidRangeToken if the token is malformed or the last
+// key it records is not in er. Since the token may have been modified by a
+// client, er should be the range that the client is allowed to read, rather
+// than a range derived from the token.
+//
+// The streaming mode of the original read is not recorded by the token, and
+// may be set on the returned options before they are passed to GetRange:
+//
+//	kr, options, err := fdb.ResumeRange(token, users)
+//	if err != nil {
+//		return nil, err
+//	}
+//	options.Mode = fdb.StreamingModeSmall
+//	kvs, next, err := tr.GetRange(kr, options).Page(100)
+func ResumeRange(token RangeToken, er ExactRange) (KeyRange, RangeOptions, error) {
+	last, reverse, limit, err := decodeRangeToken(token)
+	if err != nil {
+		return KeyRange{}, RangeOptions{}, err
+	}
+
+	begin, end := er.FDBRangeKeys()
+	bk, ek := begin.FDBKey(), end.FDBKey()
+	if bytes.Compare(last, bk) < 0 || bytes.Compare(last, ek) >= 0 {
+		return KeyRange{}, RangeOptions{}, ErrInvalidRangeToken
+	}
+
+	options := RangeOptions{Limit: limit, Reverse: reverse}
+	if reverse {
+		return KeyRange{bk, last}, options, nil
+	}
+	return KeyRange{append(last, 0x00), ek}, options, nil
+}
+
+// encodeRangeToken encodes the continuation of a range read after last.
+func encodeRangeToken(last Key, reverse bool, limit int) RangeToken {
+	var flags byte
+	if reverse {
+		flags |= rangeTokenReverse
+	}
+
+	b := []byte{rangeTokenVersion, flags}
+	b = binary.AppendUvarint(b, uint64(limit))
+	b = binary.AppendUvarint(b, uint64(len(last)))
+	return append(b, last...)
+}
+
+// decodeRangeToken returns the last key, the direction and the remaining limit
+// recorded by a RangeToken.
+func decodeRangeToken(b []byte) (Key, bool, int, error) {
+	if len(b) < 2 || b[0] != rangeTokenVersion || b[1]&^rangeTokenReverse != 0 {
+		return nil, false, 0, ErrInvalidRangeToken
+	}
+	reverse := b[1]&rangeTokenReverse != 0
+	b = b[2:]
+
+	limit, n := binary.Uvarint(b)
+	if n <= 0 || limit > math.MaxInt32 {
+		return nil, false, 0, ErrInvalidRangeToken
+	}
+	b = b[n:]
+
+	l, n := binary.Uvarint(b)
+	if n <= 0 || l != uint64(len(b)-n) {
+		return nil, false, 0, ErrInvalidRangeToken
+	}
+	last := b[n:]
+
+	return Key(last[:len(last):len(last)]), reverse, int(limit), nil
+}
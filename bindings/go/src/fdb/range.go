@@ -24,6 +24,7 @@ package fdb
 
 import (
 	"fmt"
+	"iter"
 )
 
 // KeyValue represents a single key-value pair in the database.
@@ -124,7 +125,42 @@ type RangeResult struct {
 	sr       SelectorRange
 	options  RangeOptions
 	snapshot bool
-	f        *futureKeyValueArray
+	f        rangeBatch
+}
+
+// rangeBatch is a batch of key-value pairs being read by a range read, and
+// whether more key-value pairs remain to be read after it.
+type rangeBatch interface {
+	Get() ([]KeyValue, bool, error)
+	Cancel()
+}
+
+// staticRangeBatch is the single batch of a RangeResult constructed by
+// NewRangeResult.
+type staticRangeBatch struct {
+	kvs []KeyValue
+	err error
+}
+
+func (b staticRangeBatch) Get() ([]KeyValue, bool, error) {
+	return b.kvs, false, b.err
+}
+
+func (b staticRangeBatch) Cancel() {}
+
+// NewRangeResult returns a RangeResult for a read of r with the given options,
+// whose key-value pairs are kvs, or whose reads fail with err if err is not
+// nil. It allows range reads that are not performed by a FoundationDB
+// transaction, such as those of the in-memory database of the fdbtest package,
+// to return the same type as (Transaction).GetRange. The range and options are
+// used by (RangeResult).Page to resume the read.
+func NewRangeResult(r Range, options RangeOptions, kvs []KeyValue, err error) RangeResult {
+	begin, end := r.FDBRangeKeySelectors()
+	return RangeResult{
+		sr:      SelectorRange{begin, end},
+		options: options,
+		f:       staticRangeBatch{kvs, err},
+	}
 }
 
 // GetSliceWithError returns a slice of KeyValue objects satisfying the range
@@ -181,6 +217,49 @@ func (rr RangeResult) Iterator() *RangeIterator {
 	}
 }
 
+// All returns an iterator over the key-value pairs satisfying the range
+// specified in the read that returned this RangeResult, for use with a
+// range-over-func loop:
+//
+//	for kv, err := range tr.GetRange(r, fdb.RangeOptions{}).All() {
+//		if err != nil {
+//			return nil, err
+//		}
+//		...
+//	}
+//
+// If one of the asynchronous operations associated with this range does not
+// successfully complete, the error is yielded once and iteration stops. If the
+// loop exits early, any batch that was being fetched in the background is
+// cancelled.
+func (rr RangeResult) All() iter.Seq2[KeyValue, error] {
+	return func(yield func(KeyValue, error) bool) {
+		ri := rr.Iterator()
+		for ri.Advance() {
+			kv, err := ri.Get()
+			if err != nil {
+				yield(KeyValue{}, err)
+				return
+			}
+			if !yield(kv, nil) {
+				ri.cancel()
+				return
+			}
+		}
+	}
+}
+
+// Keys is like All, but yields only the key of each key-value pair.
+func (rr RangeResult) Keys() iter.Seq2[Key, error] {
+	return func(yield func(Key, error) bool) {
+		for kv, err := range rr.All() {
+			if !yield(kv.Key, err) {
+				return
+			}
+		}
+	}
+}
+
 // RangeIterator returns the key-value pairs in the database (as KeyValue
 // objects) satisfying the range specified in a range read. RangeIterator is
 // constructed with the (RangeResult).Iterator method.
@@ -193,7 +272,7 @@ func (rr RangeResult) Iterator() *RangeIterator {
 // a transactional function passed to the Transact method of a Transactor.
 type RangeIterator struct {
 	t         *transaction
-	f         *futureKeyValueArray
+	f         rangeBatch
 	sr        SelectorRange
 	options   RangeOptions
 	iteration int
@@ -252,6 +331,16 @@ func (ri *RangeIterator) fetchNextBatch() {
 	ri.f = &f
 }
 
+// cancel cancels the batch being fetched by this iterator, if any. The first
+// batch is shared by all iterators constructed from the same RangeResult, so it
+// is never cancelled.
+func (ri *RangeIterator) cancel() {
+	if ri.f != nil && ri.iteration > 1 {
+		ri.f.Cancel()
+	}
+	ri.done = true
+}
+
 // Get returns the next KeyValue in a range read, or an error if one of the
 // asynchronous operations associated with this range did not successfully
 // complete. The Advance method of this RangeIterator must have returned true
@@ -285,6 +374,196 @@ func (ri *RangeIterator) MustGet() KeyValue {
 	return kv
 }
 
+// MappedKeyValue represents a single key-value pair read by a mapped range
+// read, together with the result of the secondary range read derived from it by
+// the mapper.
+type MappedKeyValue struct {
+	// KeyValue is the key-value pair read from the primary range (for example,
+	// an index entry).
+	KeyValue
+
+	// RangeBegin and RangeEnd are the key selectors of the secondary range
+	// read constructed by the mapper.
+	RangeBegin, RangeEnd KeySelector
+
+	// RangeResult holds the key-value pairs read from the secondary range (for
+	// example, the record referred to by an index entry).
+	RangeResult []KeyValue
+}
+
+// MappedRangeResult is a handle to the asynchronous result of a mapped range
+// read. MappedRangeResult is safe for concurrent use by multiple goroutines.
+//
+// A MappedRangeResult should not be returned from a transactional function
+// passed to the Transact method of a Transactor.
+type MappedRangeResult struct {
+	t        *transaction
+	sr       SelectorRange
+	mapper   KeyConvertible
+	options  RangeOptions
+	snapshot bool
+	f        *futureMappedKeyValueArray
+}
+
+// GetSliceWithError returns a slice of MappedKeyValue objects satisfying the
+// range specified in the read that returned this MappedRangeResult, or an error
+// if any of the asynchronous operations associated with this result did not
+// successfully complete. The current goroutine will be blocked until all reads
+// have completed.
+func (mrr MappedRangeResult) GetSliceWithError() ([]MappedKeyValue, error) {
+	var ret []MappedKeyValue
+
+	mri := mrr.Iterator()
+
+	if mrr.options.Limit != 0 {
+		mri.options.Mode = StreamingModeExact
+	} else {
+		mri.options.Mode = StreamingModeWantAll
+	}
+
+	for mri.Advance() {
+		if mri.err != nil {
+			return nil, mri.err
+		}
+		ret = append(ret, mri.mkvs...)
+		mri.index = len(mri.mkvs)
+		mri.fetchNextBatch()
+	}
+
+	return ret, nil
+}
+
+// GetSliceOrPanic returns a slice of MappedKeyValue objects satisfying the
+// range specified in the read that returned this MappedRangeResult, or panics
+// if any of the asynchronous operations associated with this result did not
+// successfully complete. The current goroutine will be blocked until all reads
+// have completed.
+func (mrr MappedRangeResult) GetSliceOrPanic() []MappedKeyValue {
+	mkvs, err := mrr.GetSliceWithError()
+	if err != nil {
+		panic(err)
+	}
+	return mkvs
+}
+
+// Iterator returns a MappedRangeIterator over the mapped key-value pairs
+// satisfying the range specified in the read that returned this
+// MappedRangeResult.
+func (mrr MappedRangeResult) Iterator() *MappedRangeIterator {
+	return &MappedRangeIterator{
+		t:         mrr.t,
+		f:         mrr.f,
+		sr:        mrr.sr,
+		mapper:    mrr.mapper,
+		options:   mrr.options,
+		iteration: 1,
+		snapshot:  mrr.snapshot,
+	}
+}
+
+// MappedRangeIterator returns the mapped key-value pairs in the database (as
+// MappedKeyValue objects) satisfying the range specified in a mapped range
+// read. MappedRangeIterator is constructed with the
+// (MappedRangeResult).Iterator method.
+//
+// You must call Advance and get a true result prior to calling Get or MustGet.
+//
+// MappedRangeIterator has the same concurrency restrictions as RangeIterator.
+type MappedRangeIterator struct {
+	t         *transaction
+	f         *futureMappedKeyValueArray
+	sr        SelectorRange
+	mapper    KeyConvertible
+	options   RangeOptions
+	iteration int
+	done      bool
+	more      bool
+	mkvs      []MappedKeyValue
+	index     int
+	err       error
+	snapshot  bool
+}
+
+// Advance attempts to advance the iterator to the next mapped key-value pair.
+// Advance returns true if there are more pairs satisfying the range, or false
+// if the range has been exhausted. You must call this before every call to Get
+// or MustGet.
+func (mri *MappedRangeIterator) Advance() bool {
+	if mri.done {
+		return false
+	}
+
+	if mri.f == nil {
+		return true
+	}
+
+	mri.mkvs, mri.more, mri.err = mri.f.Get()
+	mri.index = 0
+	mri.f = nil
+
+	if mri.err != nil || len(mri.mkvs) > 0 {
+		return true
+	}
+
+	return false
+}
+
+func (mri *MappedRangeIterator) fetchNextBatch() {
+	if !mri.more || mri.index == mri.options.Limit {
+		mri.done = true
+		return
+	}
+
+	if mri.options.Limit > 0 {
+		// Not worried about this being zero, checked equality above
+		mri.options.Limit -= mri.index
+	}
+
+	if mri.options.Reverse {
+		mri.sr.End = FirstGreaterOrEqual(mri.mkvs[mri.index-1].Key)
+	} else {
+		mri.sr.Begin = FirstGreaterThan(mri.mkvs[mri.index-1].Key)
+	}
+
+	mri.iteration++
+
+	f := mri.t.doGetMappedRange(mri.sr, mri.mapper, mri.options, mri.snapshot, mri.iteration)
+	mri.f = &f
+}
+
+// Get returns the next MappedKeyValue in a mapped range read, or an error if
+// one of the asynchronous operations associated with this range did not
+// successfully complete. The Advance method of this MappedRangeIterator must
+// have returned true prior to calling Get.
+func (mri *MappedRangeIterator) Get() (mkv MappedKeyValue, err error) {
+	if mri.err != nil {
+		err = mri.err
+		return
+	}
+
+	mkv = mri.mkvs[mri.index]
+
+	mri.index++
+
+	if mri.index == len(mri.mkvs) {
+		mri.fetchNextBatch()
+	}
+
+	return
+}
+
+// MustGet returns the next MappedKeyValue in a mapped range read, or panics if
+// one of the asynchronous operations associated with this range did not
+// successfully complete. The Advance method of this MappedRangeIterator must
+// have returned true prior to calling MustGet.
+func (mri *MappedRangeIterator) MustGet() MappedKeyValue {
+	mkv, err := mri.Get()
+	if err != nil {
+		panic(err)
+	}
+	return mkv
+}
+
 // Strinc returns the first key that would sort outside the range prefixed by
 // prefix, or an error if prefix is empty or contains only 0xFF bytes.
 func Strinc(prefix []byte) ([]byte, error) {
@@ -0,0 +1,129 @@
+/*
+ * retry.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"context"
+	"time"
+)
+
+// RetryPolicy bounds and customizes the retry loop run by the Transact and
+// ReadTransact methods of Database and Tenant. A RetryPolicy is attached to a
+// Database with (Database).WithRetryPolicy.
+//
+// The zero value of RetryPolicy behaves like the default retry loop: errors are
+// passed to (Transaction).OnError, and the transactional function is retried
+// for as long as OnError reports the error as retryable.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times the transactional function is
+	// run, including the first attempt. A value of 0 indicates no limit.
+	MaxAttempts int
+
+	// MaxElapsed is the time after which no further attempts are started,
+	// measured from the start of the first attempt. An attempt that is already
+	// running is not interrupted; use a context deadline with TransactContext
+	// to bound the total duration. A value of 0 indicates no limit.
+	MaxElapsed time.Duration
+
+	// Backoff, if set, returns the delay to wait before the given retry
+	// (starting at 1), replacing the backoff applied by OnError. The failed
+	// attempt is still passed to OnError, which decides whether the error is
+	// retryable and preserves the options set on the transaction, but the max
+	// retry delay option is set to zero beforehand so that OnError returns
+	// without waiting. A max retry delay set by the transactional function
+	// therefore has no effect.
+	Backoff func(retry int) time.Duration
+
+	// ShouldRetry, if set, is called with each Error returned by an attempt.
+	// Returning false stops the retry loop and returns the error to the caller
+	// without passing it to OnError. Errors that are not retryable are never
+	// retried, whatever ShouldRetry returns.
+	ShouldRetry func(err Error) bool
+
+	// OnRetry, if set, is called just before each retry (after any backoff),
+	// with the number of the attempt that failed and its error.
+	OnRetry func(attempt int, err Error)
+
+	// OnConflict, if set, is called whenever a commit fails with a
+	// not_committed error, with the number of the attempt and the key ranges
+	// that conflicted (see (Transaction).GetConflictingKeys). Setting
+	// OnConflict enables the report conflicting keys option on each attempt of
+	// Transact.
+	OnConflict func(attempt int, ranges []KeyRange)
+}
+
+// WithRetryPolicy returns a copy of the database handle whose Transact and
+// ReadTransact methods (and those of tenants opened from it) retry according
+// to policy. The receiver is not modified, so a policy may be applied to a
+// single call with db.WithRetryPolicy(policy).Transact(f). Passing nil restores
+// the default retry loop.
+func (d Database) WithRetryPolicy(policy *RetryPolicy) Database {
+	d.retryPolicy = policy
+	return d
+}
+
+// onError prepares tr for another attempt after the given attempt failed with
+// ep, and returns nil if the transactional function should be retried.
+func (p *RetryPolicy) onError(ctx context.Context, tr Transaction, ep Error, attempt int, start time.Time) error {
+	if p.OnConflict != nil && ep.Code == ErrNotCommitted.Code {
+		if ranges, err := tr.GetConflictingKeys(); err == nil {
+			p.OnConflict(attempt, ranges)
+		}
+	}
+
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return ep
+	}
+	if p.MaxElapsed > 0 && time.Since(start) >= p.MaxElapsed {
+		return ep
+	}
+	if p.ShouldRetry != nil && !p.ShouldRetry(ep) {
+		return ep
+	}
+
+	if p.Backoff == nil {
+		if err := tr.OnError(ep).GetContext(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := tr.Options().SetMaxRetryDelay(0); err != nil {
+			return err
+		}
+		if err := tr.OnError(ep).GetContext(ctx); err != nil {
+			return err
+		}
+
+		timer := time.NewTimer(p.Backoff(attempt))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+
+	if p.OnRetry != nil {
+		p.OnRetry(attempt, ep)
+	}
+	return nil
+}
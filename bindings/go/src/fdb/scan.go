@@ -0,0 +1,261 @@
+/*
+ * scan.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+	"sync"
+)
+
+const (
+	defaultScanBatchSize = 1000
+
+	// In an ordered scan, the number of batches of each shard that may be read
+	// ahead of the batches being passed to the callback.
+	scanReadAhead = 4
+)
+
+// ParallelScanOptions configures a ParallelScan.
+type ParallelScanOptions struct {
+	// Concurrency is the maximum number of shards read at the same time. A
+	// value of 0 uses runtime.GOMAXPROCS(0).
+	Concurrency int
+
+	// ChunkSize is the approximate size of each shard in bytes, as estimated by
+	// (Transaction).GetRangeSplitPoints. A value of 0 divides the range at the
+	// boundaries of the shards of the cluster, as returned by
+	// (Database).LocalityGetBoundaryKeys.
+	ChunkSize int64
+
+	// BatchSize is the maximum number of key-value pairs passed to each call
+	// of the callback. A value of 0 uses a batch size of 1000.
+	BatchSize int
+
+	// Ordered indicates that the callback is called with the key-value pairs
+	// of the whole range in key order, one batch at a time. Shards are still
+	// read concurrently, but only a few batches of each are read ahead of the
+	// callback.
+	Ordered bool
+}
+
+// scanCallbackError wraps an error returned by the callback of a ParallelScan,
+// so that it is not retried as a transaction error.
+type scanCallbackError struct {
+	err error
+}
+
+func (e scanCallbackError) Error() string {
+	return e.err.Error()
+}
+
+// ParallelScan reads every key-value pair in r, dividing the range into shards
+// which are each read by a separate transaction, and passes the key-value
+// pairs to f in batches. Reading a large range this way is much faster than
+// reading it with a single GetRange.
+//
+// Each shard is read from the beginning of the shard to its end, and the
+// batches of a shard are passed to f in key order. Unless options.Ordered is
+// set, f is called concurrently with the batches of different shards. If a
+// transaction fails with a retryable error, such as transaction_too_old when a
+// shard takes longer than five seconds to read, reading continues with a new
+// transaction from the last key passed to f. As a result, the scan does not
+// read a consistent snapshot of r.
+//
+// ParallelScan returns the first error returned by f or encountered while
+// reading, after cancelling the reads that are still in progress.
+func ParallelScan(ctx context.Context, db Database, r ExactRange, options ParallelScanOptions, f func(kvs []KeyValue) error) error {
+	shards, err := scanShards(ctx, db, r, options.ChunkSize)
+	if err != nil {
+		return err
+	}
+
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	batchSize := options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultScanBatchSize
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	// In an ordered scan, each shard passes its batches to the calling
+	// goroutine through its own channel.
+	var ordered []chan []KeyValue
+	if options.Ordered {
+		ordered = make([]chan []KeyValue, len(shards))
+		for i := range ordered {
+			ordered[i] = make(chan []KeyValue, scanReadAhead)
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i, shard := range shards {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				fail(ctx.Err())
+				return
+			}
+
+			deliver := f
+			if options.Ordered {
+				ch := ordered[i]
+				deliver = func(kvs []KeyValue) error {
+					select {
+					case ch <- kvs:
+						return nil
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+			}
+
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				if options.Ordered {
+					defer close(ordered[i])
+				}
+
+				if err := scanShard(ctx, db, shard, batchSize, deliver); err != nil {
+					fail(err)
+				}
+			}()
+		}
+	}()
+
+	if options.Ordered {
+	batches:
+		for _, ch := range ordered {
+			for {
+				select {
+				case kvs, ok := <-ch:
+					if !ok {
+						continue batches
+					}
+					if err := f(kvs); err != nil {
+						fail(err)
+						break batches
+					}
+				case <-ctx.Done():
+					fail(ctx.Err())
+					break batches
+				}
+			}
+		}
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// scanShards divides r into the shards read by ParallelScan.
+func scanShards(ctx context.Context, db Database, r ExactRange, chunkSize int64) ([]KeyRange, error) {
+	bk, ek := r.FDBRangeKeys()
+	begin, end := bk.FDBKey(), ek.FDBKey()
+
+	var points []Key
+	if chunkSize > 0 {
+		ret, err := db.ReadTransactContext(ctx, func(rtr ReadTransaction) (interface{}, error) {
+			return rtr.GetRangeSplitPoints(r, chunkSize).Get()
+		})
+		if err != nil {
+			return nil, err
+		}
+		points = ret.([]Key)
+	} else {
+		var err error
+		points, err = db.LocalityGetBoundaryKeys(r, 0, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var shards []KeyRange
+	prev := begin
+	for _, p := range points {
+		if bytes.Compare(p, prev) <= 0 || bytes.Compare(p, end) >= 0 {
+			continue
+		}
+		shards = append(shards, KeyRange{prev, p})
+		prev = p
+	}
+	return append(shards, KeyRange{prev, end}), nil
+}
+
+// scanShard reads shard in batches of at most batchSize key-value pairs,
+// passing each to deliver. When a transaction is retried, reading resumes
+// after the last key passed to deliver.
+func scanShard(ctx context.Context, db Database, shard KeyRange, batchSize int, deliver func([]KeyValue) error) error {
+	begin := shard.Begin.FDBKey()
+
+	_, err := db.ReadTransactContext(ctx, func(rtr ReadTransaction) (interface{}, error) {
+		for {
+			kr := KeyRange{begin, shard.End}
+			kvs, err := rtr.GetRange(kr, RangeOptions{Limit: batchSize, Mode: StreamingModeWantAll}).GetSliceWithError()
+			if err != nil {
+				return nil, err
+			}
+			if len(kvs) > 0 {
+				if err := deliver(kvs); err != nil {
+					return nil, scanCallbackError{err}
+				}
+				last := kvs[len(kvs)-1].Key
+				begin = append(last[:len(last):len(last)], 0x00)
+			}
+			if len(kvs) < batchSize {
+				return nil, nil
+			}
+		}
+	})
+
+	var cbErr scanCallbackError
+	if errors.As(err, &cbErr) {
+		return cbErr.err
+	}
+	return err
+}
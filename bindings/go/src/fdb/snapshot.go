@@ -81,6 +81,13 @@ func (s Snapshot) GetRange(r Range, options RangeOptions) RangeResult {
 	return s.getRange(r, options, true)
 }
 
+// GetMappedRange is equivalent to (Transaction).GetMappedRange, performed as a
+// snapshot read. Note that the cluster does not currently support snapshot
+// mapped range reads, which fail with an unsupported_operation error.
+func (s Snapshot) GetMappedRange(r Range, mapper KeyConvertible, options RangeOptions) MappedRangeResult {
+	return s.getMappedRange(r, mapper, options, true)
+}
+
 // GetReadVersion is equivalent to (Transaction).GetReadVersion, performed as
 // a snapshot read.
 func (s Snapshot) GetReadVersion() FutureInt64 {
@@ -0,0 +1,464 @@
+/*
+ * status.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Cluster Status
+
+// Package status provides typed access to the status of a FoundationDB
+// cluster, as reported by the \xff\xff/status/json key of the special key
+// space. This is the same document printed by "status json" in fdbcli.
+//
+// Only the most commonly used parts of the document are decoded. Fields that
+// are not described by the types of this package are ignored, and fields whose
+// value does not have the expected type are left as their zero value, so that
+// documents produced by newer or older versions of the cluster can still be
+// read.
+//
+// For a description of the fields of the document, see
+// https://apple.github.io/foundationdb/mr-status.html.
+package status
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+// StatusKey is the key of the special key space whose value is the status
+// document of the cluster.
+const StatusKey = "\xff\xff/status/json"
+
+// Status is the status document of a cluster.
+type Status struct {
+	// Cluster is the status of the cluster, as determined by the cluster
+	// controller. It is mostly empty if the cluster controller could not be
+	// reached.
+	Cluster Cluster `json:"cluster"`
+
+	// Client is the status of the cluster as seen by the client which read
+	// the document.
+	Client Client `json:"client"`
+}
+
+// Client is the status of the cluster as seen by a client.
+type Client struct {
+	DatabaseStatus DatabaseStatus     `json:"database_status"`
+	Coordinators   ClientCoordinators `json:"coordinators"`
+	ClusterFile    ClusterFile        `json:"cluster_file"`
+	Messages       []Message          `json:"messages"`
+	Timestamp      int64              `json:"timestamp"`
+}
+
+// DatabaseStatus summarizes the availability and health of the database.
+type DatabaseStatus struct {
+	Available bool `json:"available"`
+	Healthy   bool `json:"healthy"`
+}
+
+// ClientCoordinators describes the reachability of the coordinators.
+type ClientCoordinators struct {
+	QuorumReachable bool          `json:"quorum_reachable"`
+	Coordinators    []Coordinator `json:"coordinators"`
+}
+
+// Coordinator describes a single coordinator.
+type Coordinator struct {
+	Address   string `json:"address"`
+	Reachable bool   `json:"reachable"`
+	Protocol  string `json:"protocol"`
+}
+
+// ClusterFile describes the cluster file used by the client.
+type ClusterFile struct {
+	Path     string `json:"path"`
+	UpToDate bool   `json:"up_to_date"`
+}
+
+// Message is a message describing a condition of the cluster or client, such
+// as "unreachable_processes" or "status_incomplete".
+type Message struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// Cluster is the status of a cluster, as determined by its cluster controller.
+type Cluster struct {
+	DatabaseAvailable          bool    `json:"database_available"`
+	FullReplication            bool    `json:"full_replication"`
+	Generation                 int64   `json:"generation"`
+	ConnectionString           string  `json:"connection_string"`
+	ProtocolVersion            string  `json:"protocol_version"`
+	ClusterControllerTimestamp int64   `json:"cluster_controller_timestamp"`
+	DegradedProcesses          int     `json:"degraded_processes"`
+	ActivePrimaryDC            string  `json:"active_primary_dc"`
+	MaintenanceZone            string  `json:"maintenance_zone"`
+	MaintenanceSecondsLeft     float64 `json:"maintenance_seconds_remaining"`
+
+	Messages       []Message          `json:"messages"`
+	RecoveryState  RecoveryState      `json:"recovery_state"`
+	FaultTolerance FaultTolerance     `json:"fault_tolerance"`
+	Configuration  Configuration      `json:"configuration"`
+	Processes      map[string]Process `json:"processes"`
+	Data           Data               `json:"data"`
+	QoS            QoS                `json:"qos"`
+	Workload       Workload           `json:"workload"`
+	LatencyProbe   LatencyProbe       `json:"latency_probe"`
+	Layers         Layers             `json:"layers"`
+}
+
+// RecoveryState describes the progress of the most recent recovery of the
+// transaction system. Name is "fully_recovered" once recovery has completed.
+type RecoveryState struct {
+	Name                      string  `json:"name"`
+	Description               string  `json:"description"`
+	SecondsSinceLastRecovered float64 `json:"seconds_since_last_recovered"`
+	ActiveGenerations         int     `json:"active_generations"`
+}
+
+// FaultTolerance is the number of fault domains that may fail without loss of
+// availability or data.
+type FaultTolerance struct {
+	MaxZoneFailuresWithoutLosingAvailability int `json:"max_zone_failures_without_losing_availability"`
+	MaxZoneFailuresWithoutLosingData         int `json:"max_zone_failures_without_losing_data"`
+}
+
+// Configuration is the database configuration, as set with "configure" in
+// fdbcli.
+type Configuration struct {
+	RedundancyMode    string `json:"redundancy_mode"`
+	StorageEngine     string `json:"storage_engine"`
+	UsableRegions     int    `json:"usable_regions"`
+	CoordinatorsCount int    `json:"coordinators_count"`
+	CommitProxies     int    `json:"commit_proxies"`
+	GrvProxies        int    `json:"grv_proxies"`
+	Resolvers         int    `json:"resolvers"`
+	Logs              int    `json:"logs"`
+	TenantMode        string `json:"tenant_mode"`
+}
+
+// Process is the status of a single fdbserver process, keyed by process ID in
+// (Cluster).Processes.
+type Process struct {
+	Address          string            `json:"address"`
+	MachineID        string            `json:"machine_id"`
+	FaultDomain      string            `json:"fault_domain"`
+	Locality         map[string]string `json:"locality"`
+	Version          string            `json:"version"`
+	CommandLine      string            `json:"command_line"`
+	ClassType        string            `json:"class_type"`
+	ClassSource      string            `json:"class_source"`
+	Degraded         bool              `json:"degraded"`
+	Excluded         bool              `json:"excluded"`
+	UnderMaintenance bool              `json:"under_maintenance"`
+	UptimeSeconds    float64           `json:"uptime_seconds"`
+	RunLoopBusy      float64           `json:"run_loop_busy"`
+	CPU              ProcessCPU        `json:"cpu"`
+	Memory           ProcessMemory     `json:"memory"`
+	Disk             ProcessDisk       `json:"disk"`
+	Roles            []Role            `json:"roles"`
+	Messages         []Message         `json:"messages"`
+}
+
+// HasRole reports whether the process has a role with the given name, such as
+// "storage" or "log".
+func (p Process) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ProcessCPU is the CPU usage of a process.
+type ProcessCPU struct {
+	UsageCores float64 `json:"usage_cores"`
+}
+
+// ProcessMemory is the memory usage of a process.
+type ProcessMemory struct {
+	AvailableBytes int64 `json:"available_bytes"`
+	LimitBytes     int64 `json:"limit_bytes"`
+	UsedBytes      int64 `json:"used_bytes"`
+	RSSBytes       int64 `json:"rss_bytes"`
+}
+
+// ProcessDisk is the disk usage of a process.
+type ProcessDisk struct {
+	FreeBytes  int64   `json:"free_bytes"`
+	TotalBytes int64   `json:"total_bytes"`
+	Busy       float64 `json:"busy"`
+}
+
+// Role is a role played by a process, such as "storage", "log" or
+// "commit_proxy". Fields which do not apply to a role are zero.
+type Role struct {
+	Role string `json:"role"`
+	ID   string `json:"id"`
+
+	StoredBytes             int64 `json:"stored_bytes"`
+	KVStoreUsedBytes        int64 `json:"kvstore_used_bytes"`
+	KVStoreFreeBytes        int64 `json:"kvstore_free_bytes"`
+	KVStoreAvailableBytes   int64 `json:"kvstore_available_bytes"`
+	KVStoreTotalBytes       int64 `json:"kvstore_total_bytes"`
+	QueueDiskUsedBytes      int64 `json:"queue_disk_used_bytes"`
+	QueueDiskFreeBytes      int64 `json:"queue_disk_free_bytes"`
+	QueueDiskAvailableBytes int64 `json:"queue_disk_available_bytes"`
+	QueueDiskTotalBytes     int64 `json:"queue_disk_total_bytes"`
+
+	DataVersion    int64 `json:"data_version"`
+	DurableVersion int64 `json:"durable_version"`
+	DataLag        Lag   `json:"data_lag"`
+	DurabilityLag  Lag   `json:"durability_lag"`
+
+	InputBytes   Rate `json:"input_bytes"`
+	DurableBytes Rate `json:"durable_bytes"`
+
+	// TSS reports whether the storage server is a testing storage server.
+	TSS bool `json:"tss"`
+}
+
+// Rate is a counter and its rate of change, in events per second.
+type Rate struct {
+	Hz        float64 `json:"hz"`
+	Counter   int64   `json:"counter"`
+	Roughness float64 `json:"roughness"`
+}
+
+// Lag is how far a process is behind, in time and in versions.
+type Lag struct {
+	Seconds  float64 `json:"seconds"`
+	Versions int64   `json:"versions"`
+}
+
+// Data describes the data stored in the cluster and its distribution.
+type Data struct {
+	State                                 DataState     `json:"state"`
+	TotalKVSizeBytes                      int64         `json:"total_kv_size_bytes"`
+	TotalDiskUsedBytes                    int64         `json:"total_disk_used_bytes"`
+	SystemKVSizeBytes                     int64         `json:"system_kv_size_bytes"`
+	PartitionsCount                       int           `json:"partitions_count"`
+	AveragePartitionSizeBytes             int64         `json:"average_partition_size_bytes"`
+	LeastOperatingSpaceBytesStorageServer int64         `json:"least_operating_space_bytes_storage_server"`
+	LeastOperatingSpaceBytesLogServer     int64         `json:"least_operating_space_bytes_log_server"`
+	MovingData                            MovingData    `json:"moving_data"`
+	TeamTrackers                          []TeamTracker `json:"team_trackers"`
+}
+
+// DataState describes the replication health of the data in the cluster. Name
+// is "healthy" when data is fully replicated and not being moved.
+type DataState struct {
+	Healthy              bool   `json:"healthy"`
+	Name                 string `json:"name"`
+	Description          string `json:"description"`
+	MinReplicasRemaining int    `json:"min_replicas_remaining"`
+}
+
+// MovingData describes the data being moved between storage servers by data
+// distribution.
+type MovingData struct {
+	InFlightBytes     int64 `json:"in_flight_bytes"`
+	InQueueBytes      int64 `json:"in_queue_bytes"`
+	TotalWrittenBytes int64 `json:"total_written_bytes"`
+	HighestPriority   int   `json:"highest_priority"`
+}
+
+// TeamTracker describes the data distribution state of the primary or remote
+// region.
+type TeamTracker struct {
+	Primary          bool      `json:"primary"`
+	InFlightBytes    int64     `json:"in_flight_bytes"`
+	UnhealthyServers int       `json:"unhealthy_servers"`
+	State            DataState `json:"state"`
+}
+
+// QoS describes the rate at which the cluster admits transactions, and what is
+// limiting that rate.
+type QoS struct {
+	PerformanceLimitedBy               LimitReason `json:"performance_limited_by"`
+	BatchPerformanceLimitedBy          LimitReason `json:"batch_performance_limited_by"`
+	TransactionsPerSecondLimit         float64     `json:"transactions_per_second_limit"`
+	BatchTransactionsPerSecondLimit    float64     `json:"batch_transactions_per_second_limit"`
+	ReleasedTransactionsPerSecond      float64     `json:"released_transactions_per_second"`
+	BatchReleasedTransactionsPerSecond float64     `json:"batch_released_transactions_per_second"`
+	WorstQueueBytesLogServer           int64       `json:"worst_queue_bytes_log_server"`
+	WorstQueueBytesStorageServer       int64       `json:"worst_queue_bytes_storage_server"`
+	LimitingQueueBytesStorageServer    int64       `json:"limiting_queue_bytes_storage_server"`
+	WorstDataLagStorageServer          Lag         `json:"worst_data_lag_storage_server"`
+	LimitingDataLagStorageServer       Lag         `json:"limiting_data_lag_storage_server"`
+	WorstDurabilityLagStorageServer    Lag         `json:"worst_durability_lag_storage_server"`
+	LimitingDurabilityLagStorageServer Lag         `json:"limiting_durability_lag_storage_server"`
+}
+
+// LimitReason describes what is limiting the transaction rate. Name is
+// "workload" when the cluster is not saturated.
+type LimitReason struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	ReasonID       int    `json:"reason_id"`
+	ReasonServerID string `json:"reason_server_id"`
+}
+
+// Workload describes the operations performed by clients of the cluster.
+type Workload struct {
+	Operations   WorkloadOperations   `json:"operations"`
+	Bytes        WorkloadBytes        `json:"bytes"`
+	Keys         WorkloadKeys         `json:"keys"`
+	Transactions WorkloadTransactions `json:"transactions"`
+}
+
+// WorkloadOperations counts the read and write operations performed.
+type WorkloadOperations struct {
+	Reads            Rate `json:"reads"`
+	Writes           Rate `json:"writes"`
+	ReadRequests     Rate `json:"read_requests"`
+	LowPriorityReads Rate `json:"low_priority_reads"`
+	LocationRequests Rate `json:"location_requests"`
+	MemoryErrors     Rate `json:"memory_errors"`
+}
+
+// WorkloadBytes counts the bytes read and written.
+type WorkloadBytes struct {
+	Read    Rate `json:"read"`
+	Written Rate `json:"written"`
+}
+
+// WorkloadKeys counts the keys read.
+type WorkloadKeys struct {
+	Read Rate `json:"read"`
+}
+
+// WorkloadTransactions counts the transactions started, committed and
+// conflicted.
+type WorkloadTransactions struct {
+	Started                  Rate `json:"started"`
+	StartedImmediatePriority Rate `json:"started_immediate_priority"`
+	StartedDefaultPriority   Rate `json:"started_default_priority"`
+	StartedBatchPriority     Rate `json:"started_batch_priority"`
+	Committed                Rate `json:"committed"`
+	Conflicted               Rate `json:"conflicted"`
+	RejectedForQueuedTooLong Rate `json:"rejected_for_queued_too_long"`
+}
+
+// LatencyProbe is the latency of operations performed by the cluster
+// controller while generating the status document, in seconds.
+type LatencyProbe struct {
+	TransactionStartSeconds                  float64 `json:"transaction_start_seconds"`
+	ImmediatePriorityTransactionStartSeconds float64 `json:"immediate_priority_transaction_start_seconds"`
+	BatchPriorityTransactionStartSeconds     float64 `json:"batch_priority_transaction_start_seconds"`
+	ReadSeconds                              float64 `json:"read_seconds"`
+	CommitSeconds                            float64 `json:"commit_seconds"`
+}
+
+// Layers holds the status reported by layers such as backup and DR, which is
+// stored in the cluster by the layers themselves.
+type Layers struct {
+	// Valid reports whether the layer status could be read.
+	Valid bool
+
+	// Error describes why the layer status could not be read.
+	Error string
+
+	// Status is the undecoded status of each layer, keyed by layer name.
+	Status map[string]json.RawMessage
+}
+
+// UnmarshalJSON decodes the layers object of the status document, in which
+// the status of each layer is stored alongside the _valid and _error fields.
+// A value that is not an object is decoded as an empty Layers.
+func (l *Layers) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		*l = Layers{}
+		return nil
+	}
+
+	layers := Layers{Status: make(map[string]json.RawMessage)}
+	for name, value := range raw {
+		switch name {
+		case "_valid":
+			json.Unmarshal(value, &layers.Valid)
+		case "_error":
+			json.Unmarshal(value, &layers.Error)
+		default:
+			layers.Status[name] = value
+		}
+	}
+
+	*l = layers
+	return nil
+}
+
+// Healthy reports whether the client considers the database to be available
+// and healthy, which requires among other things that recovery has completed
+// and that data is fully replicated.
+func (s Status) Healthy() bool {
+	return s.Client.DatabaseStatus.Available && s.Client.DatabaseStatus.Healthy
+}
+
+// MovingDataBytes returns the number of bytes that data distribution is
+// moving or has queued to move between storage servers.
+func (s Status) MovingDataBytes() int64 {
+	return s.Cluster.Data.MovingData.InFlightBytes + s.Cluster.Data.MovingData.InQueueBytes
+}
+
+// ProcessesWithRole returns the processes which have a role with the given
+// name, such as "storage" or "log", keyed by process ID.
+func (s Status) ProcessesWithRole(role string) map[string]Process {
+	processes := make(map[string]Process)
+	for id, p := range s.Cluster.Processes {
+		if p.HasRole(role) {
+			processes[id] = p
+		}
+	}
+	return processes
+}
+
+// Parse decodes a status document, such as the value of StatusKey or the
+// output of "status json" in fdbcli.
+func Parse(data []byte) (Status, error) {
+	var s Status
+	if err := json.Unmarshal(data, &s); err != nil {
+		// Fields of an unexpected type are left unset, and the rest of the
+		// document is still decoded.
+		var typeErr *json.UnmarshalTypeError
+		if !errors.As(err, &typeErr) {
+			return Status{}, err
+		}
+	}
+	return s, nil
+}
+
+// Get reads and decodes the status document of the cluster. Generating the
+// document may take up to several seconds on a large cluster.
+func Get(rt fdb.ReadTransactor) (Status, error) {
+	ret, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		return rtr.Get(fdb.Key(StatusKey)).Get()
+	})
+	if err != nil {
+		return Status{}, err
+	}
+
+	data := ret.([]byte)
+	if data == nil {
+		return Status{}, errors.New("status document is not available")
+	}
+	return Parse(data)
+}
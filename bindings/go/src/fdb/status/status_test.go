@@ -0,0 +1,153 @@
+package status
+
+import (
+	"testing"
+)
+
+const document = `{
+	"client": {
+		"coordinators": {
+			"coordinators": [{"address": "127.0.0.1:4500", "reachable": true, "protocol": "0fdb00b073000000"}],
+			"quorum_reachable": true
+		},
+		"database_status": {"available": true, "healthy": true},
+		"messages": [],
+		"timestamp": 1700000000,
+		"cluster_file": {"path": "/etc/foundationdb/fdb.cluster", "up_to_date": true}
+	},
+	"cluster": {
+		"database_available": true,
+		"generation": 2,
+		"protocol_version": "fdb00b073000000",
+		"new_field_from_a_future_version": {"nested": [1, 2, 3]},
+		"recovery_state": {"name": "fully_recovered", "description": "Recovery complete.", "active_generations": 1},
+		"configuration": {"redundancy_mode": "double", "storage_engine": "ssd-redwood-1", "usable_regions": 1},
+		"processes": {
+			"a1": {
+				"address": "127.0.0.1:4500",
+				"locality": {"zoneid": "z1", "machineid": "m1"},
+				"class_type": "storage",
+				"run_loop_busy": 0.25,
+				"roles": [
+					{"role": "storage", "id": "s1", "stored_bytes": 1000, "data_lag": {"seconds": 0.5, "versions": 500000}},
+					{"role": "coordinator"}
+				]
+			},
+			"b2": {
+				"address": "127.0.0.1:4501",
+				"class_type": "transaction",
+				"roles": [{"role": "log", "id": "l1", "queue_disk_used_bytes": 2000}]
+			}
+		},
+		"data": {
+			"state": {"healthy": false, "name": "healthy_rebalancing", "min_replicas_remaining": 2},
+			"total_kv_size_bytes": 123456,
+			"moving_data": {"in_flight_bytes": 100, "in_queue_bytes": 50, "total_written_bytes": 1000, "highest_priority": 100}
+		},
+		"qos": {
+			"performance_limited_by": {"name": "workload", "description": "The database is not being saturated by the workload."},
+			"transactions_per_second_limit": 1.5e+21,
+			"worst_data_lag_storage_server": {"seconds": 1.5, "versions": 1500000}
+		},
+		"workload": {
+			"transactions": {"committed": {"hz": 12.5, "counter": 100, "roughness": 0.1}}
+		},
+		"latency_probe": {"read_seconds": 0.001, "commit_seconds": 0.02},
+		"layers": {
+			"_valid": true,
+			"backup": {"instances_running": 1}
+		}
+	}
+}`
+
+func TestParse(t *testing.T) {
+	s, err := Parse([]byte(document))
+	if err != nil {
+		t.Fatalf("failed to parse status: %v", err)
+	}
+
+	if !s.Healthy() {
+		t.Error("expected the database to be healthy")
+	}
+	if s.Cluster.RecoveryState.Name != "fully_recovered" {
+		t.Errorf("unexpected recovery state %q", s.Cluster.RecoveryState.Name)
+	}
+	if s.Cluster.Configuration.RedundancyMode != "double" {
+		t.Errorf("unexpected redundancy mode %q", s.Cluster.Configuration.RedundancyMode)
+	}
+	if n := s.MovingDataBytes(); n != 150 {
+		t.Errorf("expected 150 bytes of moving data, got %d", n)
+	}
+	if s.Cluster.QoS.PerformanceLimitedBy.Name != "workload" {
+		t.Errorf("unexpected limiting reason %q", s.Cluster.QoS.PerformanceLimitedBy.Name)
+	}
+	if s.Cluster.QoS.WorstDataLagStorageServer.Versions != 1500000 {
+		t.Errorf("unexpected worst data lag %+v", s.Cluster.QoS.WorstDataLagStorageServer)
+	}
+	if hz := s.Cluster.Workload.Transactions.Committed.Hz; hz != 12.5 {
+		t.Errorf("expected 12.5 committed transactions per second, got %f", hz)
+	}
+	if s.Cluster.LatencyProbe.CommitSeconds != 0.02 {
+		t.Errorf("unexpected commit latency %f", s.Cluster.LatencyProbe.CommitSeconds)
+	}
+
+	p, ok := s.Cluster.Processes["a1"]
+	if !ok {
+		t.Fatal("expected process a1")
+	}
+	if p.Locality["zoneid"] != "z1" {
+		t.Errorf("unexpected locality %v", p.Locality)
+	}
+	if len(p.Roles) != 2 || p.Roles[0].StoredBytes != 1000 || p.Roles[0].DataLag.Seconds != 0.5 {
+		t.Errorf("unexpected roles %+v", p.Roles)
+	}
+
+	storage := s.ProcessesWithRole("storage")
+	if _, ok := storage["a1"]; !ok || len(storage) != 1 {
+		t.Errorf("expected a single storage process, got %v", storage)
+	}
+	if logs := s.ProcessesWithRole("log"); len(logs) != 1 {
+		t.Errorf("expected a single log process, got %v", logs)
+	}
+
+	if !s.Cluster.Layers.Valid {
+		t.Error("expected layer status to be valid")
+	}
+	if _, ok := s.Cluster.Layers.Status["backup"]; !ok || len(s.Cluster.Layers.Status) != 1 {
+		t.Errorf("unexpected layer status %v", s.Cluster.Layers.Status)
+	}
+}
+
+func TestParseUnexpectedTypes(t *testing.T) {
+	doc := `{
+		"client": {"database_status": {"available": true, "healthy": "yes"}},
+		"cluster": {
+			"generation": "two",
+			"data": {"moving_data": {"in_flight_bytes": 10, "in_queue_bytes": 5}},
+			"layers": false
+		}
+	}`
+
+	s, err := Parse([]byte(doc))
+	if err != nil {
+		t.Fatalf("failed to parse status: %v", err)
+	}
+	if s.Healthy() {
+		t.Error("expected a health status of the wrong type to be ignored")
+	}
+	if s.Cluster.Generation != 0 {
+		t.Errorf("expected a generation of the wrong type to be ignored, got %d", s.Cluster.Generation)
+	}
+	if n := s.MovingDataBytes(); n != 15 {
+		t.Errorf("expected 15 bytes of moving data, got %d", n)
+	}
+	if s.Cluster.Layers.Valid {
+		t.Error("expected layers of the wrong type to be ignored")
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	if _, err := Parse([]byte(`{"cluster": `)); err == nil {
+		t.Error("expected a truncated document to fail to parse")
+	}
+}
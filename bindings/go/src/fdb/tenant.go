@@ -0,0 +1,181 @@
+/*
+ * tenant.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+// #define FDB_API_VERSION 800
+// #include <foundationdb/fdb_c.h>
+import "C"
+
+import (
+	"context"
+	"runtime"
+)
+
+// Tenant is a handle to a FoundationDB tenant. Tenant is a lightweight object
+// that may be efficiently copied, and is safe for concurrent use by multiple
+// goroutines.
+//
+// A tenant is a named, isolated key-space within a database. All keys read or
+// written by transactions created from a Tenant are transparently prefixed with
+// the tenant's prefix, so a tenant cannot observe or modify data belonging to
+// other tenants. Opening a tenant does not check that it exists; operations on
+// transactions of a tenant that does not exist will fail with a
+// tenant_not_found error.
+//
+// Like Database, modifications to a tenant are usually made via transactions,
+// which are usually created and committed automatically by the
+// (Tenant).Transact method.
+type Tenant struct {
+	*tenant
+}
+
+type tenant struct {
+	ptr *C.FDBTenant
+	db  Database
+}
+
+func (t *tenant) destroy() {
+	C.fdb_tenant_destroy(t.ptr)
+}
+
+// OpenTenant returns a handle to the tenant with the given name in this
+// database. The tenant does not need to exist when it is opened, but must be
+// created before any transaction created from it can succeed.
+func (d Database) OpenTenant(name KeyConvertible) (Tenant, error) {
+	nb := name.FDBKey()
+
+	var outt *C.FDBTenant
+	if err := C.fdb_database_open_tenant(d.ptr, byteSliceToPtr(nb), C.int(len(nb)), &outt); err != 0 {
+		return Tenant{}, Error{int(err)}
+	}
+
+	t := &tenant{outt, d}
+	// tenants are reference counted by the C library, which keeps them alive for as
+	// long as any transaction created from them is still in use.
+	runtime.SetFinalizer(t, (*tenant).destroy)
+
+	return Tenant{t}, nil
+}
+
+// MustOpenTenant is like OpenTenant but panics if the tenant cannot be opened.
+func (d Database) MustOpenTenant(name KeyConvertible) Tenant {
+	t, err := d.OpenTenant(name)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// GetDatabase returns a handle to the database containing this tenant.
+func (t Tenant) GetDatabase() Database {
+	return t.tenant.db
+}
+
+// CreateTransaction returns a new FoundationDB transaction operating within
+// this tenant. It is generally preferable to use the (Tenant).Transact method,
+// which handles automatically creating and committing a transaction with
+// appropriate retry behavior.
+func (t Tenant) CreateTransaction() (Transaction, error) {
+	defer runtime.KeepAlive(t.tenant)
+
+	var outt *C.FDBTransaction
+
+	if err := C.fdb_tenant_create_transaction(t.ptr, &outt); err != 0 {
+		return Transaction{}, Error{int(err)}
+	}
+
+	tr := &transaction{outt, t.db}
+	// see (Database).CreateTransaction for why the GC is responsible for destroying transactions.
+	runtime.SetFinalizer(tr, (*transaction).destroy)
+
+	return Transaction{tr}, nil
+}
+
+// GetID returns the (future) unique numeric identifier assigned to this tenant
+// by the cluster. The future will be set with a tenant_not_found error if the
+// tenant does not exist.
+func (t Tenant) GetID() FutureInt64 {
+	defer runtime.KeepAlive(t.tenant)
+
+	return &futureInt64{
+		future: newFutureWithDb(t.db.database, nil, C.fdb_tenant_get_id(t.ptr)),
+	}
+}
+
+// Transact runs a caller-provided function inside a retry loop, providing it
+// with a newly created Transaction operating within this tenant. The retry and
+// commit semantics are identical to those of (Database).Transact.
+//
+// See the Transactor interface for an example of using Transact with
+// Transaction and Database objects; Tenant may be used in the same way.
+func (t Tenant) Transact(f func(Transaction) (interface{}, error)) (interface{}, error) {
+	tr, err := t.CreateTransaction()
+	// Any error here is non-retryable
+	if err != nil {
+		return nil, err
+	}
+
+	return transact(context.Background(), tr, f)
+}
+
+// TransactContext is like Transact, but stops retrying and returns ctx.Err()
+// once ctx is done. See (Database).TransactContext for details.
+func (t Tenant) TransactContext(ctx context.Context, f func(Transaction) (interface{}, error)) (interface{}, error) {
+	tr, err := t.CreateTransaction()
+	// Any error here is non-retryable
+	if err != nil {
+		return nil, err
+	}
+
+	return transact(ctx, tr, f)
+}
+
+// ReadTransact runs a caller-provided function inside a retry loop, providing
+// it with a newly created Transaction operating within this tenant (as a
+// ReadTransaction). The retry semantics are identical to those of
+// (Database).ReadTransact.
+//
+// See the ReadTransactor interface for an example of using ReadTransact with
+// Transaction, Snapshot and Database objects; Tenant may be used in the same
+// way.
+func (t Tenant) ReadTransact(f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	tr, err := t.CreateTransaction()
+	if err != nil {
+		// Any error here is non-retryable
+		return nil, err
+	}
+
+	return readTransact(context.Background(), tr, f)
+}
+
+// ReadTransactContext is like ReadTransact, but stops retrying and returns
+// ctx.Err() once ctx is done. See (Database).ReadTransactContext for details.
+func (t Tenant) ReadTransactContext(ctx context.Context, f func(ReadTransaction) (interface{}, error)) (interface{}, error) {
+	tr, err := t.CreateTransaction()
+	if err != nil {
+		// Any error here is non-retryable
+		return nil, err
+	}
+
+	return readTransact(ctx, tr, f)
+}
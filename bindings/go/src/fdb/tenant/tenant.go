@@ -0,0 +1,321 @@
+/*
+ * tenant.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go Tenant Management
+
+// Package tenant provides functions for managing the tenants of a FoundationDB
+// cluster. Tenants are created, deleted, renamed and listed by reading and
+// writing the \xff\xff/management/tenant/ module of the special key space, so
+// all operations are transactional and may be composed with other
+// transactional functions. Every operation sets the raw access option on its
+// transaction, which is required to access the key space outside of tenants
+// when tenant mode is on, so reads and writes of the tenant map are treated in
+// the same way.
+//
+// To read and write data within a tenant, open it with
+// (fdb.Database).OpenTenant.
+//
+// For general guidance on tenants, see
+// https://apple.github.io/foundationdb/tenants.html.
+package tenant
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/apple/foundationdb/bindings/go/src/fdb"
+)
+
+const (
+	mapPrefix    = "\xff\xff/management/tenant/map/"
+	renamePrefix = "\xff\xff/management/tenant/rename/"
+)
+
+var (
+	// ErrTenantNotFound is returned when deleting, renaming or looking up a
+	// tenant that does not exist (tenant_not_found).
+	ErrTenantNotFound = fdb.ErrTenantNotFound
+
+	// ErrTenantExists is returned when creating a tenant, or renaming a tenant
+	// to a name, that is already in use (tenant_already_exists).
+	ErrTenantExists = fdb.ErrTenantAlreadyExists
+)
+
+// TenantLockState describes whether a tenant is currently locked and, if so,
+// which operations the lock prevents.
+type TenantLockState string
+
+const (
+	// LockStateUnlocked indicates that the tenant can be read and written.
+	LockStateUnlocked TenantLockState = "unlocked"
+
+	// LockStateReadOnly indicates that the tenant can be read but not written.
+	LockStateReadOnly TenantLockState = "read only"
+
+	// LockStateLocked indicates that the tenant can be neither read nor written.
+	LockStateLocked TenantLockState = "locked"
+)
+
+// TenantMapEntry is the metadata describing a single tenant, as reported by
+// the tenant map in the special key space.
+type TenantMapEntry struct {
+	// ID is the unique numeric identifier assigned to the tenant by the cluster.
+	ID int64
+
+	// Name is the name of the tenant.
+	Name fdb.Key
+
+	// Prefix is the key prefix under which the tenant's data is stored.
+	Prefix fdb.Key
+
+	// TenantGroup is the name of the group the tenant is assigned to, or nil if
+	// the tenant does not belong to a group.
+	TenantGroup fdb.Key
+
+	// LockState is the current lock state of the tenant.
+	LockState TenantLockState
+
+	// LockID identifies the holder of the lock, and is empty if the tenant is
+	// unlocked.
+	LockID string
+}
+
+// binaryJSON is the representation of arbitrary bytes used in the JSON
+// documents of the tenant map.
+type binaryJSON struct {
+	Base64 string `json:"base64"`
+}
+
+func (b *binaryJSON) decode() (fdb.Key, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(b.Base64)
+}
+
+// UnmarshalJSON decodes a tenant map entry from the JSON document stored as
+// the value of a key in the tenant map.
+func (e *TenantMapEntry) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		ID          int64       `json:"id"`
+		Name        *binaryJSON `json:"name"`
+		Prefix      *binaryJSON `json:"prefix"`
+		TenantGroup *binaryJSON `json:"tenant_group"`
+		LockState   string      `json:"lock_state"`
+		LockID      string      `json:"lock_id"`
+	}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var err error
+	entry := TenantMapEntry{
+		ID:        raw.ID,
+		LockState: TenantLockState(raw.LockState),
+		LockID:    raw.LockID,
+	}
+
+	if entry.Name, err = raw.Name.decode(); err != nil {
+		return err
+	}
+	if entry.Prefix, err = raw.Prefix.decode(); err != nil {
+		return err
+	}
+	if entry.TenantGroup, err = raw.TenantGroup.decode(); err != nil {
+		return err
+	}
+
+	*e = entry
+	return nil
+}
+
+func mapKey(name fdb.KeyConvertible) fdb.Key {
+	return append(fdb.Key(mapPrefix), name.FDBKey()...)
+}
+
+func exists(tr fdb.Transaction, key fdb.Key) (bool, error) {
+	v, err := tr.Get(key).Get()
+	if err != nil {
+		return false, err
+	}
+	return v != nil, nil
+}
+
+// Existence checks are only performed when run using a database or tenant.
+// Callers using a transaction are expected to check existence themselves if
+// required. Once a check has completed it is not repeated when the
+// transaction is retried, so that operations remain idempotent if their
+// commit result is unknown.
+func skipExistenceCheck(t fdb.Transactor) bool {
+	_, ok := t.(fdb.Transaction)
+	return ok
+}
+
+// Create creates a new tenant with the given name in the cluster. If t is not
+// a Transaction, Create returns ErrTenantExists if the tenant already exists.
+// The existence check is performed only once, so Create may succeed if the
+// tenant is concurrently created by someone else while it is being retried.
+func Create(t fdb.Transactor, name fdb.KeyConvertible) error {
+	checked := skipExistenceCheck(t)
+
+	_, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.Options().SetRawAccess()
+		tr.Options().SetSpecialKeySpaceEnableWrites()
+		key := mapKey(name)
+
+		if !checked {
+			found, err := exists(tr, key)
+			if err != nil {
+				return nil, err
+			}
+			checked = true
+			if found {
+				return nil, ErrTenantExists
+			}
+		}
+
+		tr.Set(key, []byte{})
+		return nil, nil
+	})
+	return err
+}
+
+// Delete removes the tenant with the given name from the cluster. The tenant
+// must be empty. If t is not a Transaction, Delete returns ErrTenantNotFound if
+// the tenant does not exist. The existence check is performed only once, so
+// Delete may succeed if the tenant is concurrently deleted by someone else
+// while it is being retried.
+func Delete(t fdb.Transactor, name fdb.KeyConvertible) error {
+	checked := skipExistenceCheck(t)
+
+	_, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.Options().SetRawAccess()
+		tr.Options().SetSpecialKeySpaceEnableWrites()
+		key := mapKey(name)
+
+		if !checked {
+			found, err := exists(tr, key)
+			if err != nil {
+				return nil, err
+			}
+			checked = true
+			if !found {
+				return nil, ErrTenantNotFound
+			}
+		}
+
+		tr.Clear(key)
+		return nil, nil
+	})
+	return err
+}
+
+// Rename changes the name of an existing tenant. The tenant's data and ID are
+// unaffected. If t is not a Transaction, Rename returns ErrTenantNotFound if
+// oldName does not exist and ErrTenantExists if newName is already in use.
+// As with Create and Delete, the existence checks are performed only once.
+func Rename(t fdb.Transactor, oldName, newName fdb.KeyConvertible) error {
+	checked := skipExistenceCheck(t)
+
+	_, err := t.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		tr.Options().SetRawAccess()
+		tr.Options().SetSpecialKeySpaceEnableWrites()
+
+		if !checked {
+			oldFuture := tr.Get(mapKey(oldName))
+			newFuture := tr.Get(mapKey(newName))
+
+			oldValue, err := oldFuture.Get()
+			if err != nil {
+				return nil, err
+			}
+			newValue, err := newFuture.Get()
+			if err != nil {
+				return nil, err
+			}
+
+			checked = true
+			if oldValue == nil {
+				return nil, ErrTenantNotFound
+			}
+			if newValue != nil {
+				return nil, ErrTenantExists
+			}
+		}
+
+		tr.Set(append(fdb.Key(renamePrefix), oldName.FDBKey()...), newName.FDBKey())
+		return nil, nil
+	})
+	return err
+}
+
+// Get returns the metadata of the tenant with the given name, or
+// ErrTenantNotFound if the tenant does not exist.
+func Get(rt fdb.ReadTransactor, name fdb.KeyConvertible) (TenantMapEntry, error) {
+	ret, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		rtr.Options().SetRawAccess()
+
+		v, err := rtr.Get(mapKey(name)).Get()
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, ErrTenantNotFound
+		}
+
+		var entry TenantMapEntry
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return nil, err
+		}
+		return entry, nil
+	})
+	if err != nil {
+		return TenantMapEntry{}, err
+	}
+	return ret.(TenantMapEntry), nil
+}
+
+// List returns the metadata of the tenants whose names fall in the range
+// [begin, end), in name order. If limit is non-zero, at most limit tenants are
+// returned.
+func List(rt fdb.ReadTransactor, begin, end fdb.KeyConvertible, limit int) ([]TenantMapEntry, error) {
+	ret, err := rt.ReadTransact(func(rtr fdb.ReadTransaction) (interface{}, error) {
+		rtr.Options().SetRawAccess()
+
+		kr := fdb.KeyRange{Begin: mapKey(begin), End: mapKey(end)}
+		kvs, err := rtr.GetRange(kr, fdb.RangeOptions{Limit: limit}).GetSliceWithError()
+		if err != nil {
+			return nil, err
+		}
+
+		entries := make([]TenantMapEntry, len(kvs))
+		for i, kv := range kvs {
+			if err := json.Unmarshal(kv.Value, &entries[i]); err != nil {
+				return nil, err
+			}
+		}
+		return entries, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret.([]TenantMapEntry), nil
+}
@@ -0,0 +1,58 @@
+package tenant
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestTenantMapEntryUnmarshalJSON(t *testing.T) {
+	doc := `{
+		"id": 7,
+		"name": {"base64": "Y3VzdG9tZXI=", "printable": "customer"},
+		"prefix": {"base64": "AAAAAAAAAAc=", "printable": "\\x00\\x00\\x00\\x00\\x00\\x00\\x00\\x07"},
+		"tenant_group": {"base64": "Z3JvdXA=", "printable": "group"},
+		"lock_state": "read only",
+		"lock_id": "0123456789abcdef0123456789abcdef"
+	}`
+
+	var entry TenantMapEntry
+	if err := json.Unmarshal([]byte(doc), &entry); err != nil {
+		t.Fatalf("failed to decode tenant map entry: %v", err)
+	}
+
+	if entry.ID != 7 {
+		t.Errorf("expected ID 7, got %d", entry.ID)
+	}
+	if !bytes.Equal(entry.Name, []byte("customer")) {
+		t.Errorf("expected name customer, got %v", entry.Name)
+	}
+	if !bytes.Equal(entry.Prefix, []byte{0, 0, 0, 0, 0, 0, 0, 7}) {
+		t.Errorf("unexpected prefix %v", entry.Prefix)
+	}
+	if !bytes.Equal(entry.TenantGroup, []byte("group")) {
+		t.Errorf("expected tenant group group, got %v", entry.TenantGroup)
+	}
+	if entry.LockState != LockStateReadOnly {
+		t.Errorf("expected lock state %q, got %q", LockStateReadOnly, entry.LockState)
+	}
+	if entry.LockID != "0123456789abcdef0123456789abcdef" {
+		t.Errorf("unexpected lock ID %q", entry.LockID)
+	}
+}
+
+func TestTenantMapEntryWithoutGroup(t *testing.T) {
+	doc := `{"id": 1, "name": {"base64": "YQ=="}, "prefix": {"base64": "AAAAAAAAAAE="}, "lock_state": "unlocked"}`
+
+	var entry TenantMapEntry
+	if err := json.Unmarshal([]byte(doc), &entry); err != nil {
+		t.Fatalf("failed to decode tenant map entry: %v", err)
+	}
+
+	if entry.TenantGroup != nil {
+		t.Errorf("expected no tenant group, got %v", entry.TenantGroup)
+	}
+	if entry.LockState != LockStateUnlocked {
+		t.Errorf("expected lock state %q, got %q", LockStateUnlocked, entry.LockState)
+	}
+}
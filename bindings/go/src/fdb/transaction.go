@@ -36,6 +36,7 @@ type ReadTransaction interface {
 	Get(key KeyConvertible) FutureByteSlice
 	GetKey(sel Selectable) FutureKey
 	GetRange(r Range, options RangeOptions) RangeResult
+	GetMappedRange(r Range, mapper KeyConvertible, options RangeOptions) MappedRangeResult
 	GetReadVersion() FutureInt64
 	GetDatabase() Database
 	Snapshot() Snapshot
@@ -47,6 +48,45 @@ type ReadTransaction interface {
 	ReadTransactor
 }
 
+// A WriteTransaction can modify a FoundationDB database. Transaction satisfies
+// the WriteTransaction interface.
+//
+// Together with ReadTransaction, WriteTransaction allows code that operates on
+// a transaction to accept types that wrap a Transaction, such as ones that
+// enforce quotas on the keys written or log every mutation, as well as fake
+// transactions in tests.
+type WriteTransaction interface {
+	Set(key KeyConvertible, value []byte)
+	Clear(key KeyConvertible)
+	ClearRange(er ExactRange)
+
+	Add(key KeyConvertible, param []byte)
+	BitAnd(key KeyConvertible, param []byte)
+	BitOr(key KeyConvertible, param []byte)
+	BitXor(key KeyConvertible, param []byte)
+	Max(key KeyConvertible, param []byte)
+	Min(key KeyConvertible, param []byte)
+	ByteMax(key KeyConvertible, param []byte)
+	ByteMin(key KeyConvertible, param []byte)
+	CompareAndClear(key KeyConvertible, param []byte)
+	AppendIfFits(key KeyConvertible, param []byte)
+	SetVersionstampedKey(key KeyConvertible, param []byte)
+	SetVersionstampedValue(key KeyConvertible, param []byte)
+
+	AddReadConflictRange(er ExactRange) error
+	AddReadConflictKey(key KeyConvertible) error
+	AddWriteConflictRange(er ExactRange) error
+	AddWriteConflictKey(key KeyConvertible) error
+
+	Watch(key KeyConvertible) FutureNil
+	Commit() FutureNil
+}
+
+var (
+	_ ReadTransaction  = Transaction{}
+	_ WriteTransaction = Transaction{}
+)
+
 // Transaction is a handle to a FoundationDB transaction. Transaction is a
 // lightweight object that may be efficiently copied, and is safe for concurrent
 // use by multiple goroutines.
@@ -313,6 +353,70 @@ func (t Transaction) GetRange(r Range, options RangeOptions) RangeResult {
 	return t.getRange(r, options, false)
 }
 
+func (t *transaction) doGetMappedRange(r Range, mapper KeyConvertible, options RangeOptions, snapshot bool, iteration int) futureMappedKeyValueArray {
+	begin, end := r.FDBRangeKeySelectors()
+	bsel := begin.FDBKeySelector()
+	esel := end.FDBKeySelector()
+	bkey := bsel.Key.FDBKey()
+	ekey := esel.Key.FDBKey()
+	mkey := mapper.FDBKey()
+
+	return futureMappedKeyValueArray{
+		future: newFuture(t, C.fdb_transaction_get_mapped_range(
+			t.ptr,
+			byteSliceToPtr(bkey),
+			C.int(len(bkey)),
+			C.fdb_bool_t(boolToInt(bsel.OrEqual)),
+			C.int(bsel.Offset),
+			byteSliceToPtr(ekey),
+			C.int(len(ekey)),
+			C.fdb_bool_t(boolToInt(esel.OrEqual)),
+			C.int(esel.Offset),
+			byteSliceToPtr(mkey),
+			C.int(len(mkey)),
+			C.int(options.Limit),
+			C.int(0),
+			C.FDBStreamingMode(options.Mode-1),
+			C.int(iteration),
+			C.fdb_bool_t(boolToInt(snapshot)),
+			C.fdb_bool_t(boolToInt(options.Reverse)),
+		))}
+}
+
+func (t *transaction) getMappedRange(r Range, mapper KeyConvertible, options RangeOptions, snapshot bool) MappedRangeResult {
+	f := t.doGetMappedRange(r, mapper, options, snapshot, 1)
+	begin, end := r.FDBRangeKeySelectors()
+	return MappedRangeResult{
+		t:        t,
+		sr:       SelectorRange{begin, end},
+		mapper:   mapper,
+		options:  options,
+		snapshot: snapshot,
+		f:        &f,
+	}
+}
+
+// GetMappedRange performs a mapped range read, joining each key-value pair in
+// the range r with the result of a secondary range read derived from it. This
+// allows an index and the records it refers to be read in a single round trip.
+//
+// The mapper is a packed tuple (typically a tuple.Tuple) describing how to
+// construct the secondary range from each key-value pair read from r. Elements
+// of the form "{K[i]}" and "{V[i]}" are replaced with the i-th element of the
+// unpacked key or value respectively, and a final element of "{...}" reads every
+// key with the resulting prefix. For example, an index with keys of the form
+// ("index", value, id) may be joined with records stored under ("record", id)
+// using the mapper tuple.Tuple{"record", "{K[2]}", "{...}"}.
+//
+// The returned MappedRangeResult yields a MappedKeyValue for each key-value pair
+// in r, carrying the joined rows in its RangeResult field. Mapped range reads
+// currently require a serializable (non-snapshot) read with read-your-writes
+// enabled, and fail with a get_mapped_range_reads_your_writes error if the
+// secondary range intersects a write made by this transaction.
+func (t Transaction) GetMappedRange(r Range, mapper KeyConvertible, options RangeOptions) MappedRangeResult {
+	return t.getMappedRange(r, mapper, options, false)
+}
+
 func (t *transaction) getEstimatedRangeSizeBytes(beginKey Key, endKey Key) FutureInt64 {
 	return &futureInt64{
 		future: newFuture(t, C.fdb_transaction_get_estimated_range_size_bytes(
@@ -444,6 +548,38 @@ func (t *transaction) getApproximateSize() FutureInt64 {
 	}
 }
 
+var conflictingKeysPrefix = Key("\xff\xff/transaction/conflicting_keys/")
+
+// GetConflictingKeys returns the key ranges that caused the most recent commit
+// of this transaction to fail with a not_committed error. The
+// SetReportConflictingKeys transaction option must have been set before the
+// commit, and GetConflictingKeys must be called before the transaction is
+// passed to OnError or reset. When using (Database).Transact, see the
+// OnConflict field of RetryPolicy instead.
+func (t Transaction) GetConflictingKeys() ([]KeyRange, error) {
+	kr := KeyRange{conflictingKeysPrefix, append(conflictingKeysPrefix[:len(conflictingKeysPrefix):len(conflictingKeysPrefix)], 0xff, 0xff)}
+	kvs, err := t.GetRange(kr, RangeOptions{}).GetSliceWithError()
+	if err != nil {
+		return nil, err
+	}
+
+	// The conflicting ranges are reported as their boundaries, with a value of
+	// "1" at the beginning of each range and "0" at its end.
+	var ranges []KeyRange
+	var begin Key
+	for _, kv := range kvs {
+		key := kv.Key[len(conflictingKeysPrefix):]
+		if string(kv.Value) == "1" {
+			begin = key
+		} else if begin != nil {
+			ranges = append(ranges, KeyRange{begin, key})
+			begin = nil
+		}
+	}
+
+	return ranges, nil
+}
+
 // Returns a future that is the approximate transaction size so far in this
 // transaction, which is the summation of the estimated size of mutations,
 // read conflict ranges, and write conflict ranges.
@@ -451,6 +587,24 @@ func (t Transaction) GetApproximateSize() FutureInt64 {
 	return t.getApproximateSize()
 }
 
+// GetTotalCost returns a future that is the cost of the transaction so far, as
+// estimated from the bytes it has read and written for the purpose of tag
+// throttling.
+func (t Transaction) GetTotalCost() FutureInt64 {
+	return &futureInt64{
+		future: newFuture(t.transaction, C.fdb_transaction_get_total_cost(t.ptr)),
+	}
+}
+
+// GetTagThrottledDuration returns a future that is the total time, in seconds,
+// that this transaction has been delayed by tag throttling (for example, of a
+// tag set with SetTag or SetAutoThrottleTag).
+func (t Transaction) GetTagThrottledDuration() FutureFloat64 {
+	return &futureFloat64{
+		future: newFuture(t.transaction, C.fdb_transaction_get_tag_throttled_duration(t.ptr)),
+	}
+}
+
 // Reset rolls back a transaction, completely resetting it to its initial
 // state. This is logically equivalent to destroying the transaction and
 // creating a new one.
@@ -0,0 +1,143 @@
+/*
+ * watch.go
+ *
+ * This source file is part of the FoundationDB open source project
+ *
+ * Copyright 2013-2024 Apple Inc. and the FoundationDB project authors
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// FoundationDB Go API
+
+package fdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+)
+
+// WatchEvent is delivered by (Database).WatchKey each time the value of the
+// watched key is observed to change.
+type WatchEvent struct {
+	// Value is the value of the key, or nil if the key does not exist.
+	Value []byte
+
+	// Version is the read version at which Value was read.
+	Version int64
+
+	// Err is set if the watch could not be re-armed. An event with a non-nil
+	// Err is always the last event delivered before the channel is closed.
+	Err error
+}
+
+// WatchKey watches the value of key and returns a channel on which a WatchEvent
+// is delivered with the current value of the key, and then again each time the
+// value is observed to change. The channel is closed once ctx is done.
+//
+// Each time the watch fires, WatchKey reads the value and creates a new watch in
+// a fresh transaction, committing it so that the watch reports changes made by
+// other transactions. As with (Transaction).Watch, a change that is reverted
+// before the watch is re-armed may not be reported, and only the most recent
+// value is delivered if the receiver falls behind.
+//
+// Each call to WatchKey holds at most one outstanding watch, which counts
+// toward the limit set with (DatabaseOptions).SetMaxWatches. If the limit is
+// reached, or any other non-retryable error occurs, an event with Err set (for
+// example to a too_many_watches error) is delivered and the channel is closed.
+// Retryable errors are retried with the same backoff as (Database).Transact.
+func (d Database) WatchKey(ctx context.Context, key KeyConvertible) <-chan WatchEvent {
+	ch := make(chan WatchEvent)
+	go d.watchKey(ctx, key.FDBKey(), ch)
+	return ch
+}
+
+func (d Database) watchKey(ctx context.Context, key Key, ch chan<- WatchEvent) {
+	defer close(ch)
+
+	send := func(ev WatchEvent) bool {
+		select {
+		case ch <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	var last *WatchEvent
+
+	for {
+		var watch FutureNil
+
+		ret, err := d.TransactContext(ctx, func(tr Transaction) (interface{}, error) {
+			// A watch created by an attempt that is being retried has been
+			// cancelled by the reset of the transaction.
+			watch = nil
+
+			value, err := tr.Get(key).Get()
+			if err != nil {
+				return nil, err
+			}
+			version, err := tr.GetReadVersion().Get()
+			if err != nil {
+				return nil, err
+			}
+
+			watch = tr.Watch(key)
+			return WatchEvent{Value: value, Version: version}, nil
+		})
+		if err != nil {
+			if watch != nil {
+				watch.Cancel()
+			}
+			if ctx.Err() == nil {
+				send(WatchEvent{Err: err})
+			}
+			return
+		}
+
+		ev := ret.(WatchEvent)
+		if last == nil || (last.Value == nil) != (ev.Value == nil) || !bytes.Equal(last.Value, ev.Value) {
+			if !send(ev) {
+				watch.Cancel()
+				return
+			}
+			last = &ev
+		}
+
+		if err := watch.GetContext(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+
+			// Use the standard retry loop backoff for errors reported by the
+			// watch itself, and give up on those that are not retryable.
+			var ep Error
+			if !errors.As(err, &ep) {
+				send(WatchEvent{Err: err})
+				return
+			}
+			tr, err := d.CreateTransaction()
+			if err == nil {
+				err = tr.OnError(ep).GetContext(ctx)
+			}
+			if err != nil {
+				if ctx.Err() == nil {
+					send(WatchEvent{Err: err})
+				}
+				return
+			}
+		}
+	}
+}